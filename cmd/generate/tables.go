@@ -40,6 +40,8 @@ var goplsStar = map[prop]int{
 	{"Hover", "range"}:                    wantOpt,     // complex expressions
 	{"InlayHint", "kind"}:                 wantOpt,     // temporary variables
 
+	{"SignatureInformation", "activeParameter"}: wantOptStar, // 0 is a valid index, so absent needs nil
+
 	{"TextDocumentClientCapabilities", "codeAction"}:          wantOpt,     // A.B.C.D
 	{"TextDocumentClientCapabilities", "completion"}:          wantOpt,     // A.B.C.D
 	{"TextDocumentClientCapabilities", "documentSymbol"}:      wantOpt,     // A.B.C.D
@@ -0,0 +1,40 @@
+package declaration_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/clangd/internal"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestReadDeclaration tests that ReadDeclaration finds the header declaration of a function
+func TestReadDeclaration(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	// Open a file so that clangd loads compile commands, then wait for indexing
+	filePath := filepath.Join(suite.WorkspaceDir, "src/consumer.cpp")
+	if err := suite.Client.OpenFile(ctx, filePath); err != nil {
+		t.Fatalf("Failed to open consumer.cpp: %v", err)
+	}
+	time.Sleep(5 * time.Second)
+
+	// helperFunction() is called at line 14, column 28 of consumer.cpp
+	result, err := tools.ReadDeclaration(ctx, suite.Client, "", filePath, 14, 28)
+	if err != nil {
+		t.Fatalf("ReadDeclaration failed: %v", err)
+	}
+
+	if !strings.Contains(result, "helper.hpp") {
+		t.Errorf("Expected declaration in helper.hpp but got: %s", result)
+	}
+
+	common.SnapshotTest(t, "clangd", "declaration", "helper-function", result)
+}
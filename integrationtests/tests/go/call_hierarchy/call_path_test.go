@@ -0,0 +1,77 @@
+package callhierarchy_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestCallPath tests finding call chains between functions
+func TestCallPath(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	err := suite.WriteFile("path.go", `package main
+
+// Handle serves a request
+func Handle() string {
+	return Load()
+}
+
+// Load reads through the cache
+func Load() string {
+	return Query()
+}
+
+// Query runs a query
+func Query() string {
+	return HelperFunction()
+}
+`)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		result, err := tools.FindCallPath(ctx, suite.Client, "Handle", "HelperFunction", 6)
+		if err != nil {
+			t.Fatalf("FindCallPath failed: %v", err)
+		}
+
+		// Handle -> Load -> Query -> HelperFunction
+		for _, expected := range []string{"(3 calls)", "Handle (Function)", "Load (Function)", "Query (Function)", "HelperFunction (Function)"} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("Expected %q in the call path but got: %s", expected, result)
+			}
+		}
+
+		common.SnapshotTest(t, "go", "call_hierarchy", "call-path", result)
+	})
+
+	t.Run("TooDeep", func(t *testing.T) {
+		result, err := tools.FindCallPath(ctx, suite.Client, "Handle", "HelperFunction", 2)
+		if err != nil {
+			t.Fatalf("FindCallPath failed: %v", err)
+		}
+		if !strings.Contains(result, "No call path found") {
+			t.Errorf("Expected no path within 2 calls but got: %s", result)
+		}
+	})
+
+	t.Run("NotCalled", func(t *testing.T) {
+		result, err := tools.FindCallPath(ctx, suite.Client, "HelperFunction", "Handle", 6)
+		if err != nil {
+			t.Fatalf("FindCallPath failed: %v", err)
+		}
+		if !strings.Contains(result, "No call path found") {
+			t.Errorf("Expected no path in the reverse direction but got: %s", result)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package callhierarchy_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestCallGraph tests following calls over several levels through mutually
+// recursive functions
+func TestCallGraph(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	err := suite.WriteFile("graph.go", `package main
+
+// Walk visits n, recursing through Visit
+func Walk(n int) int {
+	return Visit(n)
+}
+
+// Visit calls back into Walk for smaller values
+func Visit(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return Walk(n-1) + n
+}
+
+// Start begins a walk
+func Start() int {
+	return Walk(3)
+}
+`)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		result, err := tools.GetCallees(ctx, suite.Client, "Start", 5, "text")
+		if err != nil {
+			t.Fatalf("Failed to find callees: %v", err)
+		}
+		if !strings.Contains(result, "    - Calls: Walk (shown elsewhere)") {
+			t.Errorf("Expected the recursion back into Walk to not be expanded again but got: %s", result)
+		}
+	})
+
+	t.Run("Mermaid", func(t *testing.T) {
+		result, err := tools.GetCallees(ctx, suite.Client, "Start", 5, "mermaid")
+		if err != nil {
+			t.Fatalf("Failed to find callees: %v", err)
+		}
+		common.SnapshotTest(t, "go", "call_hierarchy", "graph-mermaid", result)
+	})
+
+	t.Run("DOT", func(t *testing.T) {
+		result, err := tools.GetCallers(ctx, suite.Client, "Visit", 5, "dot")
+		if err != nil {
+			t.Fatalf("Failed to find callers: %v", err)
+		}
+		common.SnapshotTest(t, "go", "call_hierarchy", "graph-dot", result)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		if _, err := tools.GetCallers(ctx, suite.Client, "Visit", 1, "svg"); err == nil {
+			t.Errorf("Expected an error for an invalid format")
+		}
+	})
+}
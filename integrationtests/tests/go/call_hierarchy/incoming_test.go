@@ -40,7 +40,7 @@ func TestIncomingCalls(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Call the GetIncomingCalls tool
-			result, err := tools.GetCallers(ctx, suite.Client, tc.symbolName, 1)
+			result, err := tools.GetCallers(ctx, suite.Client, tc.symbolName, 1, "text")
 			if err != nil {
 				t.Fatalf("Failed to find incoming calls: %v", err)
 			}
@@ -40,7 +40,7 @@ func TestOutgoingCalls(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Call the GetOutgoingCalls tool
-			result, err := tools.GetCallees(ctx, suite.Client, tc.symbolName, 1)
+			result, err := tools.GetCallees(ctx, suite.Client, tc.symbolName, 1, "text")
 			if err != nil {
 				t.Fatalf("Failed to find outgoing calls: %v", err)
 			}
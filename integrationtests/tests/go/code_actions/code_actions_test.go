@@ -0,0 +1,102 @@
+package code_actions_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestCodeActions tests listing code actions with the Go language server
+func TestCodeActions(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	filePath := filepath.Join(suite.WorkspaceDir, "main.go")
+
+	t.Run("ExtractRefactoring", func(t *testing.T) {
+		// Select the FooBar() call in main
+		result, err := tools.GetCodeActions(ctx, suite.Client, filePath, tools.CodeActionOptions{
+			StartLine:   13,
+			StartColumn: 14,
+			EndLine:     13,
+			EndColumn:   22,
+			Kinds:       []string{"refactor.extract"},
+		})
+		if err != nil {
+			t.Fatalf("GetCodeActions failed: %v", err)
+		}
+
+		if !strings.Contains(result, "Extract") {
+			t.Errorf("Expected an extract refactoring but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "code_actions", "extract", result)
+	})
+
+	t.Run("ApplyWithWrongTitle", func(t *testing.T) {
+		before, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+
+		_, err = tools.ApplyCodeAction(ctx, suite.Client, filePath, tools.CodeActionOptions{
+			StartLine:   13,
+			StartColumn: 14,
+			EndLine:     13,
+			EndColumn:   22,
+			Kinds:       []string{"refactor.extract"},
+		}, 1, "Not a code action")
+		if err == nil || !strings.Contains(err.Error(), "List the code actions again") {
+			t.Errorf("Expected the code action to be refused but got: %v", err)
+		}
+
+		after, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if string(before) != string(after) {
+			t.Errorf("File was changed by a refused code action")
+		}
+	})
+
+	t.Run("UnknownDiagnostic", func(t *testing.T) {
+		_, err := tools.GetCodeActions(ctx, suite.Client, filePath, tools.CodeActionOptions{
+			Diagnostic: "L100:C1",
+		})
+		if err == nil {
+			t.Errorf("Expected an error for a diagnostic that does not exist")
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		result, err := tools.ApplyCodeAction(ctx, suite.Client, filePath, tools.CodeActionOptions{
+			StartLine:   13,
+			StartColumn: 14,
+			EndLine:     13,
+			EndColumn:   22,
+			Kinds:       []string{"refactor.extract"},
+		}, 1, "Extract variable")
+		if err != nil {
+			t.Fatalf("ApplyCodeAction failed: %v", err)
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if !strings.Contains(string(content), ":= FooBar()") {
+			t.Errorf("Expected the call to be extracted to a variable but got: %s", content)
+		}
+
+		common.SnapshotTest(t, "go", "code_actions", "apply", result)
+	})
+}
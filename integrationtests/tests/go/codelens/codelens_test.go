@@ -3,6 +3,8 @@ package codelens_test
 import (
 	"context"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -14,8 +16,6 @@ import (
 
 // TestCodeLens tests the codelens functionality with the Go language server
 func TestCodeLens(t *testing.T) {
-	t.Skip("Remove this line to run codelens tool tests")
-
 	// Test GetCodeLens with a file that should have codelenses
 	t.Run("GetCodeLens", func(t *testing.T) {
 		suite := internal.GetTestSuite(t)
@@ -25,9 +25,6 @@ func TestCodeLens(t *testing.T) {
 
 		// The go.mod fixture already has an unused dependency
 
-		// Wait for LSP to process the file
-		time.Sleep(2 * time.Second)
-
 		// Test GetCodeLens
 		filePath := filepath.Join(suite.WorkspaceDir, "go.mod")
 		result, err := tools.GetCodeLens(ctx, suite.Client, filePath)
@@ -40,24 +37,27 @@ func TestCodeLens(t *testing.T) {
 			t.Errorf("Expected code lens results but got: %s", result)
 		}
 
-		// Verify we have a "go mod tidy" code lens
-		if !strings.Contains(strings.ToLower(result), "tidy") {
-			t.Errorf("Expected 'tidy' code lens but got: %s", result)
+		// Which lenses gopls offers, and in what order, differs between
+		// versions, so check for the ones every version has by title
+		for _, lens := range []struct{ title, command string }{
+			{"Run go mod tidy", "gopls.tidy"},
+			{"Check for upgrades", "gopls.check_upgrades"},
+		} {
+			pattern := `\[\d+\] Location: Lines \d+-\d+\n\s+Title: ` + regexp.QuoteMeta(lens.title) + `\n\s+Command: ` + regexp.QuoteMeta(lens.command) + `\n`
+			if !regexp.MustCompile(pattern).MatchString(result) {
+				t.Errorf("Expected a %q code lens running %s but got: %s", lens.title, lens.command, result)
+			}
 		}
-
-		common.SnapshotTest(t, "go", "codelens", "get", result)
 	})
 
 	// Test ExecuteCodeLens by running the tidy codelens command
 	t.Run("ExecuteCodeLens", func(t *testing.T) {
 		suite := internal.GetTestSuite(t)
 
-		ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+		ctx, cancel := context.WithTimeout(suite.Context, 30*time.Second)
 		defer cancel()
 
 		// The go.mod fixture already has an unused dependency
-		// Wait for LSP to process the file
-		time.Sleep(2 * time.Second)
 
 		// First get the code lenses to find the right index
 		filePath := filepath.Join(suite.WorkspaceDir, "go.mod")
@@ -71,21 +71,33 @@ func TestCodeLens(t *testing.T) {
 			t.Fatalf("Expected 'tidy' code lens but none found: %s", result)
 		}
 
-		// Typically, the tidy lens should be index 2 (1-based) for gopls, but let's log for debugging
 		t.Logf("Code lenses: %s", result)
 
-		// Execute the code lens (use index 2 which should be the tidy lens)
-		execResult, err := tools.ExecuteCodeLens(ctx, suite.Client, filePath, 2)
+		// Find the tidy lens by its title, its index differs between gopls versions
+		match := regexp.MustCompile(`\[(\d+)\] Location: .*\n\s+Title: Run go mod tidy\n`).FindStringSubmatch(result)
+		if match == nil {
+			t.Fatalf("Expected a 'Run go mod tidy' code lens but none found: %s", result)
+		}
+		index, err := strconv.Atoi(match[1])
+		if err != nil {
+			t.Fatalf("Invalid code lens index %q: %v", match[1], err)
+		}
+
+		execResult, err := tools.ExecuteCodeLens(ctx, suite.Client, filePath, index)
 		if err != nil {
 			t.Fatalf("ExecuteCodeLens failed: %v", err)
 		}
 
 		t.Logf("ExecuteCodeLens result: %s", execResult)
 
-		// Wait for LSP to update the file
-		time.Sleep(3 * time.Second)
+		// The edit to go.mod should be reported
+		if !strings.Contains(execResult, "go.mod: ") || !strings.Contains(execResult, "(applied)") {
+			t.Errorf("Expected the applied edit to go.mod to be reported but got: %s", execResult)
+		}
+
+		// The edit is applied before the command returns, so the dependency
+		// should already be removed
 
-		// Check if the file was updated (dependency should be removed)
 		updatedContent, err := suite.ReadFile("go.mod")
 		if err != nil {
 			t.Fatalf("Failed to read updated go.mod: %v", err)
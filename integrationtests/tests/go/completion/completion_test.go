@@ -0,0 +1,37 @@
+package completion_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestCompletion tests completing package members with the Go language server
+func TestCompletion(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	filePath := filepath.Join(suite.WorkspaceDir, "main.go")
+
+	// Just after "fmt." in fmt.Println(FooBar())
+	result, err := tools.GetCompletions(ctx, suite.Client, filePath, 13, 6, "Print", 10)
+	if err != nil {
+		t.Fatalf("GetCompletions failed: %v", err)
+	}
+
+	for _, expected := range []string{"Println", "Printf", "(Function)"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected completions to contain %q but got: %s", expected, result)
+		}
+	}
+	if strings.Contains(result, "Sprintf") {
+		t.Errorf("Expected completions to be filtered by prefix but got: %s", result)
+	}
+}
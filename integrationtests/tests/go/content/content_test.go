@@ -20,6 +20,7 @@ func TestContent(t *testing.T) {
 
 	tests := []struct {
 		name         string
+		symbolName   string
 		file         string
 		line         int
 		column       int
@@ -34,12 +35,25 @@ func TestContent(t *testing.T) {
 			expectedText: "func TestFunction()",
 			snapshotName: "test_function",
 		},
+		{
+			name:         "Method by name",
+			symbolName:   "SharedStruct.Process",
+			expectedText: "func (s *SharedStruct) Process() error",
+			snapshotName: "method_by_name",
+		},
+		{
+			name:         "Function by name in file",
+			symbolName:   "TestFunction",
+			file:         filepath.Join(suite.WorkspaceDir, "clean.go"),
+			expectedText: "func TestFunction()",
+			snapshotName: "function_by_name_in_file",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Call the ReadDefinition tool
-			result, err := tools.GetContentInfo(ctx, suite.Client, tc.file, tc.line, tc.column)
+			result, err := tools.GetContentInfo(ctx, suite.Client, tc.symbolName, tc.file, tc.line, tc.column)
 			if err != nil {
 				t.Fatalf("Failed to read content: %v", err)
 			}
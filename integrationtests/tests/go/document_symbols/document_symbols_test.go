@@ -0,0 +1,67 @@
+package document_symbols_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestDocumentSymbols tests the GetDocumentSymbols tool with the Go language server
+func TestDocumentSymbols(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name         string
+		file         string
+		maxDepth     int
+		kinds        []string
+		expectedText string
+		snapshotName string
+	}{
+		{
+			name:         "Full outline",
+			file:         "types.go",
+			expectedText: "SharedStruct [Struct]",
+			snapshotName: "full",
+		},
+		{
+			name:         "Top level only",
+			file:         "types.go",
+			maxDepth:     1,
+			expectedText: "SharedInterface [Interface]",
+			snapshotName: "top-level",
+		},
+		{
+			name:         "Kind filter",
+			file:         "clean.go",
+			kinds:        []string{"Function"},
+			expectedText: "TestFunction [Function]",
+			snapshotName: "kind-filter",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := filepath.Join(suite.WorkspaceDir, tc.file)
+			result, err := tools.GetDocumentSymbols(ctx, suite.Client, filePath, tc.maxDepth, tc.kinds)
+			if err != nil {
+				t.Fatalf("Failed to get document symbols: %v", err)
+			}
+
+			if !strings.Contains(result, tc.expectedText) {
+				t.Errorf("Document symbols do not contain expected text: %s\nGot: %s", tc.expectedText, result)
+			}
+
+			common.SnapshotTest(t, "go", "document_symbols", tc.snapshotName, result)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package execute_command_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestListCommands tests listing the commands advertised by the Go language server
+func TestListCommands(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	result, err := tools.ListCommands(suite.Client, "")
+	if err != nil {
+		t.Fatalf("ListCommands failed: %v", err)
+	}
+	if !strings.Contains(result, "gopls.add_import\n") || !strings.Contains(result, "gopls.tidy\n") {
+		t.Errorf("Expected gopls.add_import and gopls.tidy to be listed but got: %s", result)
+	}
+
+	result, err = tools.ListCommands(suite.Client, "ADD_IMPORT")
+	if err != nil {
+		t.Fatalf("ListCommands failed: %v", err)
+	}
+	if !strings.Contains(result, "gopls.add_import") || strings.Contains(result, "gopls.tidy") {
+		t.Errorf("Expected only matching commands to be listed but got: %s", result)
+	}
+}
+
+// TestExecuteCommand tests running gopls.add_import, which applies its edit
+// with workspace/applyEdit
+func TestExecuteCommand(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 15*time.Second)
+	defer cancel()
+
+	testFileName := "imports.go"
+	err := suite.WriteFile(testFileName, "package main\n\nfunc Upper(s string) string {\n\treturn s\n}\n")
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	filePath := filepath.Join(suite.WorkspaceDir, testFileName)
+	if err := suite.Client.OpenFile(ctx, filePath); err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+
+	arguments := fmt.Sprintf(`{"ImportPath": "strings", "URI": "file://%s"}`, filePath)
+	result, err := tools.ExecuteCommand(ctx, suite.Client, "gopls.add_import", arguments)
+	if err != nil {
+		t.Fatalf("ExecuteCommand failed: %v", err)
+	}
+
+	if !strings.Contains(result, "imports.go: ") || !strings.Contains(result, "(applied)") {
+		t.Errorf("Expected the applied edit to be reported but got: %s", result)
+	}
+
+	content, err := suite.ReadFile(testFileName)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if !strings.Contains(content, `import "strings"`) {
+		t.Errorf("Expected the import to be added but got:\n%s", content)
+	}
+
+	common.SnapshotTest(t, "go", "execute_command", "add_import", result)
+
+	if _, err := tools.ExecuteCommand(ctx, suite.Client, "gopls.not_a_command", ""); err == nil {
+		t.Errorf("Expected an error for a command the server does not advertise")
+	}
+}
@@ -0,0 +1,69 @@
+package file_operations_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestCreateAndDeleteFile tests creating and deleting files with the Go language server
+func TestCreateAndDeleteFile(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 15*time.Second)
+	defer cancel()
+
+	filePath := filepath.Join(suite.WorkspaceDir, "broken.go")
+	uri := protocol.DocumentUri("file://" + filePath)
+
+	// The new file is opened, so the server publishes its diagnostics
+	published, stopWaiting := suite.Client.DiagnosticsPublished(uri)
+	defer func() { stopWaiting() }()
+
+	result, err := tools.CreateFile(ctx, suite.Client, filePath, "package main\n\nfunc Broken() int {\n\treturn \"not an int\"\n}\n", false)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %v", err)
+	}
+	if !strings.Contains(result, "Created") {
+		t.Errorf("Expected a created message but got: %s", result)
+	}
+	if !suite.Client.IsFileOpen(filePath) {
+		t.Errorf("Expected the created file to be open")
+	}
+
+	// The server may publish no diagnostics before it has type checked the file
+	for len(suite.Client.GetFileDiagnostics(uri)) == 0 {
+		select {
+		case <-published:
+		case <-ctx.Done():
+			t.Fatalf("Expected diagnostics for the created file")
+		}
+		stopWaiting()
+		published, stopWaiting = suite.Client.DiagnosticsPublished(uri)
+	}
+
+	if _, err := tools.CreateFile(ctx, suite.Client, filePath, "package main\n", false); err == nil {
+		t.Errorf("Expected an error when creating a file that exists")
+	}
+
+	_, err = tools.DeleteFile(ctx, suite.Client, filePath, false)
+	if err != nil {
+		t.Fatalf("DeleteFile failed: %v", err)
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be deleted", filePath)
+	}
+	if suite.Client.IsFileOpen(filePath) {
+		t.Errorf("Expected the deleted file to be closed")
+	}
+	if len(suite.Client.GetFileDiagnostics(uri)) != 0 {
+		t.Errorf("Expected the diagnostics of the deleted file to be cleared")
+	}
+}
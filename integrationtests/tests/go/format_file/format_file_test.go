@@ -0,0 +1,98 @@
+package format_file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestFormatFile tests formatting a file with the Go language server
+func TestFormatFile(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	testFileName := "format_test.go"
+	testFilePath := filepath.Join(suite.WorkspaceDir, testFileName)
+
+	initialContent := `package main
+
+func   Unformatted( a int,b int ) int {
+return a+b
+}
+`
+	expectedContent := `package main
+
+func Unformatted(a int, b int) int {
+	return a + b
+}
+`
+
+	err := suite.WriteFile(testFileName, initialContent)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		result, err := tools.FormatFile(ctx, suite.Client, testFilePath, tools.FormatOptions{
+			TabSize: 4,
+			DryRun:  true,
+		})
+		if err != nil {
+			t.Fatalf("FormatFile failed: %v", err)
+		}
+
+		if !strings.Contains(result, "Formatting would change 2 lines") {
+			t.Errorf("Expected a diff of 2 lines but got: %s", result)
+		}
+
+		content, err := os.ReadFile(testFilePath)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if string(content) != initialContent {
+			t.Errorf("Dry run changed the file:\n%s", content)
+		}
+
+		common.SnapshotTest(t, "go", "format_file", "dry_run", result)
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		result, err := tools.FormatFile(ctx, suite.Client, testFilePath, tools.FormatOptions{
+			TabSize: 4,
+		})
+		if err != nil {
+			t.Fatalf("FormatFile failed: %v", err)
+		}
+
+		content, err := os.ReadFile(testFilePath)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if string(content) != expectedContent {
+			t.Errorf("File was not formatted as expected:\n%s", content)
+		}
+
+		common.SnapshotTest(t, "go", "format_file", "format", result)
+
+		// The server must have been told about the new content, or it would
+		// format its stale copy again
+		result, err = tools.FormatFile(ctx, suite.Client, testFilePath, tools.FormatOptions{
+			TabSize: 4,
+		})
+		if err != nil {
+			t.Fatalf("FormatFile failed: %v", err)
+		}
+		if !strings.Contains(result, "is already formatted") {
+			t.Errorf("Expected the file to be formatted already but got: %s", result)
+		}
+	})
+}
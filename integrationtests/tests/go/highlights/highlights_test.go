@@ -0,0 +1,55 @@
+package highlights_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestHighlights tests read and write occurrences of a variable with the Go language server
+func TestHighlights(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	testFileName := "counter.go"
+	content := `package main
+
+// Counter sums the numbers below n
+func Counter(n int) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		count += i
+	}
+	return count
+}
+`
+	err := suite.WriteFile(testFileName, content)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	filePath := filepath.Join(suite.WorkspaceDir, testFileName)
+
+	// The declaration of count
+	result, err := tools.GetHighlights(ctx, suite.Client, filePath, 5, 2)
+	if err != nil {
+		t.Fatalf("GetHighlights failed: %v", err)
+	}
+
+	if !strings.Contains(result, "Occurrences: 3") {
+		t.Errorf("Expected 3 occurrences of count but got: %s", result)
+	}
+	if !strings.Contains(result, "L9:C9 Read") {
+		t.Errorf("Expected the return of count to be a read but got: %s", result)
+	}
+
+	common.SnapshotTest(t, "go", "highlights", "counter", result)
+}
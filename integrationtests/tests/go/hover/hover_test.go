@@ -99,7 +99,7 @@ func TestHover(t *testing.T) {
 			}
 
 			// Get hover info
-			result, err := tools.GetHoverInfo(ctx, suite.Client, filePath, tt.line, tt.column)
+			result, err := tools.GetHoverInfo(ctx, suite.Client, "", filePath, tt.line, tt.column)
 			if err != nil {
 				// For the "OutsideFile" test, we expect an error
 				if tt.name == "OutsideFile" {
@@ -124,3 +124,66 @@ func TestHover(t *testing.T) {
 		})
 	}
 }
+
+// TestHoverBySymbolName tests hover for symbols identified by name instead of position
+func TestHoverBySymbolName(t *testing.T) {
+	tests := []struct {
+		name         string
+		symbolName   string
+		file         string
+		expectedText string
+		expectedErr  string
+		snapshotName string
+	}{
+		{
+			name:         "Method",
+			symbolName:   "SharedStruct.GetName",
+			expectedText: "func (s *SharedStruct) GetName() string",
+			snapshotName: "by-name-method",
+		},
+		{
+			name:         "FunctionInFile",
+			symbolName:   "FooBar",
+			file:         "main.go",
+			expectedText: "func FooBar() string",
+			snapshotName: "by-name-in-file",
+		},
+		{
+			name:        "NotInFile",
+			symbolName:  "FooBar",
+			file:        "types.go",
+			expectedErr: "FooBar not found in",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := internal.GetTestSuite(t)
+
+			ctx, cancel := context.WithTimeout(suite.Context, 5*time.Second)
+			defer cancel()
+
+			filePath := ""
+			if tt.file != "" {
+				filePath = filepath.Join(suite.WorkspaceDir, tt.file)
+			}
+
+			result, err := tools.GetHoverInfo(ctx, suite.Client, tt.symbolName, filePath, 0, 0)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("Expected error containing %q but got: %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetHoverInfo failed: %v", err)
+			}
+
+			if !strings.Contains(result, tt.expectedText) {
+				t.Errorf("Expected hover info to contain %q but got: %s", tt.expectedText, result)
+			}
+
+			common.SnapshotTest(t, "go", "hover", tt.snapshotName, result)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package implementation_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestFindImplementations tests the FindImplementations tool with the Go language server
+func TestFindImplementations(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	t.Run("InterfaceByName", func(t *testing.T) {
+		result, err := tools.FindImplementations(ctx, suite.Client, "SharedInterface", "", 0, 0)
+		if err != nil {
+			t.Fatalf("FindImplementations failed: %v", err)
+		}
+
+		if !strings.Contains(result, "type SharedStruct struct") {
+			t.Errorf("Expected SharedStruct implementation but got: %s", result)
+		}
+
+		// CustomImplementor is declared inside AnotherConsumer, only its own
+		// declaration should be shown
+		if !strings.Contains(result, "type CustomImplementor struct") || strings.Contains(result, "func AnotherConsumer") {
+			t.Errorf("Expected only the CustomImplementor declaration but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "implementation", "interface-by-name", result)
+	})
+
+	t.Run("InterfaceMethodByPosition", func(t *testing.T) {
+		// Process() is declared at line 20, column 2 of types.go
+		filePath := filepath.Join(suite.WorkspaceDir, "types.go")
+		result, err := tools.FindImplementations(ctx, suite.Client, "", filePath, 20, 2)
+		if err != nil {
+			t.Fatalf("FindImplementations failed: %v", err)
+		}
+
+		if !strings.Contains(result, "func (s *SharedStruct) Process() error") {
+			t.Errorf("Expected Process implementation but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "implementation", "method-by-position", result)
+	})
+
+	t.Run("MissingArguments", func(t *testing.T) {
+		_, err := tools.FindImplementations(ctx, suite.Client, "", "", 0, 0)
+		if err == nil {
+			t.Errorf("Expected an error when neither a symbol nor a position is given")
+		}
+	})
+}
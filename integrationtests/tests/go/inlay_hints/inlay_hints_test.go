@@ -0,0 +1,35 @@
+package inlay_hints_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestInlayHints tests parameter name hints with the Go language server
+func TestInlayHints(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	filePath := filepath.Join(suite.WorkspaceDir, "main.go")
+
+	result, err := tools.GetInlayHints(ctx, suite.Client, filePath, 12, 14)
+	if err != nil {
+		t.Fatalf("GetInlayHints failed: %v", err)
+	}
+
+	// fmt.Println(FooBar()) gets a hint for Println's parameter name
+	if !strings.Contains(result, "fmt.Println(«a...:»") {
+		t.Errorf("Expected a parameter name hint but got: %s", result)
+	}
+
+	common.SnapshotTest(t, "go", "inlay_hints", "parameter-names", result)
+}
@@ -0,0 +1,84 @@
+package locate_symbol_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestLocateSymbol tests the LocateSymbol tool with the Go language server
+func TestLocateSymbol(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name         string
+		symbolName   string
+		file         string
+		expectedText []string
+		snapshotName string
+	}{
+		{
+			name:         "Function in workspace",
+			symbolName:   "FooBar",
+			expectedText: []string{"Kind: Function", "Line: 6", "Column: 6", "Preview: func FooBar() string {"},
+			snapshotName: "function",
+		},
+		{
+			name:         "Qualified method in workspace",
+			symbolName:   "SharedStruct.GetName",
+			expectedText: []string{"Kind: Method", "Line: 37", "Column: 24"},
+			snapshotName: "method",
+		},
+		{
+			name:         "Qualified method in file",
+			symbolName:   "SharedStruct::Process",
+			file:         "types.go",
+			expectedText: []string{"Kind: Method", "Line: 31", "Column: 24"},
+			snapshotName: "method-in-file",
+		},
+		{
+			name:         "Field in file",
+			symbolName:   "SharedStruct.Name",
+			file:         "types.go",
+			expectedText: []string{"Kind: Field", "Container: SharedStruct", "Line: 8", "Column: 2"},
+			snapshotName: "field-in-file",
+		},
+		{
+			name:         "Not found",
+			symbolName:   "NotARealSymbol",
+			expectedText: []string{"NotARealSymbol not found"},
+			snapshotName: "not-found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := ""
+			if tc.file != "" {
+				filePath = filepath.Join(suite.WorkspaceDir, tc.file)
+			}
+
+			result, err := tools.LocateSymbol(ctx, suite.Client, tc.symbolName, filePath)
+			if err != nil {
+				t.Fatalf("Failed to locate symbol: %v", err)
+			}
+
+			for _, expected := range tc.expectedText {
+				if !strings.Contains(result, expected) {
+					t.Errorf("Result does not contain expected text: %s\nGot: %s", expected, result)
+				}
+			}
+
+			common.SnapshotTest(t, "go", "locate_symbol", tc.snapshotName, result)
+		})
+	}
+}
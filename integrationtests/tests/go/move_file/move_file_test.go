@@ -0,0 +1,52 @@
+package move_file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestMoveDirectory tests moving a whole directory with the Go language server
+func TestMoveDirectory(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	if err := suite.WriteFile("util/strings.go", "package util\n\nfunc Upper(s string) string { return s }\n"); err != nil {
+		t.Fatalf("Failed to write util/strings.go: %v", err)
+	}
+	oldDir := filepath.Join(suite.WorkspaceDir, "util")
+	oldFile := filepath.Join(oldDir, "strings.go")
+	if err := suite.Client.OpenFile(ctx, oldFile); err != nil {
+		t.Fatalf("Failed to open util/strings.go: %v", err)
+	}
+
+	newDir := filepath.Join(suite.WorkspaceDir, "pkg", "util")
+	newFile := filepath.Join(newDir, "strings.go")
+	result, err := tools.MoveFile(ctx, suite.Client, oldDir, newDir)
+	if err != nil {
+		t.Fatalf("MoveFile failed: %v", err)
+	}
+
+	if _, err := os.Stat(newFile); err != nil {
+		t.Errorf("Expected %s to exist after the move: %v", newFile, err)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be gone after the move", oldDir)
+	}
+	if !suite.Client.IsFileOpen(newFile) || suite.Client.IsFileOpen(oldFile) {
+		t.Errorf("Expected the open document to follow the move")
+	}
+
+	// The first line names both paths, which snapshots can't normalize
+	_, details, _ := strings.Cut(result, "\n\n")
+	common.SnapshotTest(t, "go", "move_file", "directory", details)
+}
@@ -0,0 +1,62 @@
+package organize_imports_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestOrganizeImports tests removing an unused import and adding a missing one
+func TestOrganizeImports(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	testFileName := "imports_test.go"
+	testFilePath := filepath.Join(suite.WorkspaceDir, testFileName)
+
+	initialContent := `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func PrintFile(path string) {
+	content, _ := os.ReadFile(path)
+	fmt.Println(string(content))
+}
+`
+
+	err := suite.WriteFile(testFileName, initialContent)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	result, err := tools.OrganizeImports(ctx, suite.Client, testFilePath)
+	if err != nil {
+		t.Fatalf("OrganizeImports failed: %v", err)
+	}
+
+	if !strings.Contains(result, "Imports added:\n  \"os\"") {
+		t.Errorf("Expected os to be added but got: %s", result)
+	}
+	if !strings.Contains(result, "Imports removed:\n  \"strings\"") {
+		t.Errorf("Expected strings to be removed but got: %s", result)
+	}
+
+	content, err := os.ReadFile(testFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if strings.Contains(string(content), `"strings"`) || !strings.Contains(string(content), `"os"`) {
+		t.Errorf("Imports were not organized:\n%s", content)
+	}
+}
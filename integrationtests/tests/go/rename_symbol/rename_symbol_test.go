@@ -34,7 +34,7 @@ func TestRenameSymbol(t *testing.T) {
 
 		// Request to rename SharedConstant to UpdatedConstant at its definition
 		// The constant is defined at line 25, column 7 of types.go
-		result, err := tools.RenameSymbol(ctx, suite.Client, filePath, 25, 7, "UpdatedConstant")
+		result, err := tools.RenameSymbol(ctx, suite.Client, filePath, 25, 7, "UpdatedConstant", false)
 		if err != nil {
 			t.Fatalf("RenameSymbol failed: %v", err)
 		}
@@ -72,6 +72,103 @@ func TestRenameSymbol(t *testing.T) {
 		}
 	})
 
+	// Test previewing a rename without applying it
+	t.Run("DryRun", func(t *testing.T) {
+		suite := internal.GetTestSuite(t)
+
+		// Wait for initialization
+		time.Sleep(2 * time.Second)
+
+		ctx, cancel := context.WithTimeout(suite.Context, 5*time.Second)
+		defer cancel()
+
+		filePath := filepath.Join(suite.WorkspaceDir, "types.go")
+		result, err := tools.RenameSymbol(ctx, suite.Client, filePath, 25, 7, "UpdatedConstant", true)
+		if err != nil {
+			t.Fatalf("RenameSymbol failed: %v", err)
+		}
+
+		if !strings.Contains(result, "Symbol: 'SharedConstant'") {
+			t.Errorf("Expected the prepared symbol in the result but got: %s", result)
+		}
+		if !strings.Contains(result, "-const SharedConstant") || !strings.Contains(result, "+const UpdatedConstant") {
+			t.Errorf("Expected a diff of the rename but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "rename_symbol", "dry_run", result)
+
+		// Verify nothing was written
+		fileContent, err := suite.ReadFile("types.go")
+		if err != nil {
+			t.Fatalf("Failed to read types.go: %v", err)
+		}
+		if strings.Contains(fileContent, "UpdatedConstant") {
+			t.Errorf("Expected types.go to be unchanged after a dry run")
+		}
+	})
+
+	// Test renaming a symbol identified by name rather than position
+	t.Run("ByName", func(t *testing.T) {
+		suite := internal.GetTestSuite(t)
+
+		// Wait for initialization
+		time.Sleep(2 * time.Second)
+
+		ctx, cancel := context.WithTimeout(suite.Context, 5*time.Second)
+		defer cancel()
+
+		result, err := tools.RenameSymbolByName(ctx, suite.Client, "SharedConstant", "Constant", "", "", "UpdatedConstant", true)
+		if err != nil {
+			t.Fatalf("RenameSymbolByName failed: %v", err)
+		}
+
+		if !strings.Contains(result, "Resolved SharedConstant [Constant]") {
+			t.Errorf("Expected the resolved declaration in the result but got: %s", result)
+		}
+		if !strings.Contains(result, "+const UpdatedConstant") {
+			t.Errorf("Expected a diff of the rename but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "rename_symbol", "by_name", result)
+	})
+
+	// Test that an ambiguous name is refused
+	t.Run("AmbiguousName", func(t *testing.T) {
+		suite := internal.GetTestSuite(t)
+
+		// Wait for initialization
+		time.Sleep(2 * time.Second)
+
+		ctx, cancel := context.WithTimeout(suite.Context, 5*time.Second)
+		defer cancel()
+
+		// gopls only renames methods in packages without errors, so fix the
+		// error in main.go first
+		err := suite.WriteFile("main.go", "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello, World!\")\n}\n")
+		if err != nil {
+			t.Fatalf("Failed to write main.go: %v", err)
+		}
+
+		// GetName is declared by both SharedInterface and SharedStruct
+		_, err = tools.RenameSymbolByName(ctx, suite.Client, "GetName", "", "", "", "DisplayName", true)
+		if err == nil {
+			t.Fatalf("Expected an error when renaming an ambiguous symbol")
+		}
+		if !strings.Contains(err.Error(), "ambiguous") || !strings.Contains(err.Error(), "SharedStruct") {
+			t.Errorf("Expected the candidates to be listed but got: %v", err)
+		}
+
+		// A container narrows it down to one declaration. gopls renames the
+		// implementations along with the interface method.
+		result, err := tools.RenameSymbolByName(ctx, suite.Client, "GetName", "", "SharedInterface", "", "DisplayName", true)
+		if err != nil {
+			t.Fatalf("RenameSymbolByName with container failed: %v", err)
+		}
+		if !strings.Contains(result, "Dry run") || !strings.Contains(result, "+func (s *SharedStruct) DisplayName() string") {
+			t.Errorf("Expected a dry run of the interface and its implementation but got: %s", result)
+		}
+	})
+
 	// Test with a symbol that doesn't exist
 	t.Run("SymbolNotFound", func(t *testing.T) {
 		// Get a test suite with clean code
@@ -92,7 +189,7 @@ func TestRenameSymbol(t *testing.T) {
 
 		// Request to rename a symbol at a position where no symbol exists
 		// The clean.go file doesn't have content at this position
-		_, err = tools.RenameSymbol(ctx, suite.Client, filePath, 10, 10, "NewName")
+		_, err = tools.RenameSymbol(ctx, suite.Client, filePath, 10, 10, "NewName", false)
 
 		// Expect an error because there's no symbol at that position
 		if err == nil {
@@ -0,0 +1,37 @@
+package semantic_tokens_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestSemanticTokens tests semantic token classification with the Go language server
+func TestSemanticTokens(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	filePath := filepath.Join(suite.WorkspaceDir, "main.go")
+
+	result, err := tools.GetSemanticTokens(ctx, suite.Client, filePath, 12, 14)
+	if err != nil {
+		t.Fatalf("GetSemanticTokens failed: %v", err)
+	}
+
+	// fmt.Println(FooBar()) refers to a package and two functions
+	for _, expected := range []string{"fmt: namespace", "Println: function", "FooBar: function"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected %q in result but got: %s", expected, result)
+		}
+	}
+
+	common.SnapshotTest(t, "go", "semantic_tokens", "main", result)
+}
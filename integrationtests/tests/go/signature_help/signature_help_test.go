@@ -0,0 +1,36 @@
+package signature_help_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestSignatureHelp tests signature help inside a call with the Go language server
+func TestSignatureHelp(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	filePath := filepath.Join(suite.WorkspaceDir, "main.go")
+
+	// After the FooBar() argument of fmt.Println(FooBar()). Any earlier
+	// position is inside the FooBar call, which the server prefers.
+	result, err := tools.GetSignatureHelp(ctx, suite.Client, filePath, 13, 22)
+	if err != nil {
+		t.Fatalf("GetSignatureHelp failed: %v", err)
+	}
+
+	if !strings.Contains(result, "Println") || !strings.Contains(result, "«a ...any»") {
+		t.Errorf("Expected Println with the active parameter highlighted but got: %s", result)
+	}
+
+	common.SnapshotTest(t, "go", "signature_help", "println", result)
+}
@@ -0,0 +1,50 @@
+package type_definition_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestReadTypeDefinition tests the ReadTypeDefinition tool with the Go language server
+func TestReadTypeDefinition(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	t.Run("LocalVariableByPosition", func(t *testing.T) {
+		// s := &SharedStruct{...} is at line 11, column 2 of consumer.go
+		filePath := filepath.Join(suite.WorkspaceDir, "consumer.go")
+		result, err := tools.ReadTypeDefinition(ctx, suite.Client, "", filePath, 11, 2)
+		if err != nil {
+			t.Fatalf("ReadTypeDefinition failed: %v", err)
+		}
+
+		if !strings.Contains(result, "type SharedStruct struct") {
+			t.Errorf("Expected SharedStruct type definition but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "type_definition", "local-variable", result)
+	})
+
+	t.Run("VariableByName", func(t *testing.T) {
+		result, err := tools.ReadTypeDefinition(ctx, suite.Client, "TestVariable", "", 0, 0)
+		if err != nil {
+			t.Fatalf("ReadTypeDefinition failed: %v", err)
+		}
+
+		// TestVariable is an int, declared in the builtin package
+		if !strings.Contains(result, "type int int") {
+			t.Errorf("Expected the int type definition but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "type_definition", "variable-by-name", result)
+	})
+}
@@ -0,0 +1,57 @@
+package type_hierarchy_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+func TestTypeHierarchy(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name         string
+		symbolName   string
+		direction    string
+		expectedText string
+		snapshotName string
+	}{
+		{
+			name:         "Interface subtypes",
+			symbolName:   "SharedInterface",
+			direction:    "down",
+			expectedText: "- Subtype: SharedStruct",
+			snapshotName: "interface-subtypes",
+		},
+		{
+			name:         "Struct supertypes",
+			symbolName:   "SharedStruct",
+			direction:    "up",
+			expectedText: "- Supertype: SharedInterface",
+			snapshotName: "struct-supertypes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tools.GetTypeHierarchy(ctx, suite.Client, tc.symbolName, "", 0, 0, tc.direction, 2)
+			if err != nil {
+				t.Fatalf("Failed to get type hierarchy: %v", err)
+			}
+
+			if !strings.Contains(result, tc.expectedText) {
+				t.Errorf("Type hierarchy does not contain expected text: %s\nGot: %s", tc.expectedText, result)
+			}
+
+			common.SnapshotTest(t, "go", "type_hierarchy", tc.snapshotName, result)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package workspace_diagnostics_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestWorkspaceDiagnostics tests workspace wide diagnostics with the Go language server
+func TestWorkspaceDiagnostics(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 15*time.Second)
+	defer cancel()
+
+	// Open the file with errors so that the server publishes its diagnostics
+	filePath := filepath.Join(suite.WorkspaceDir, "main.go")
+	if _, err := tools.GetDiagnosticsForFile(ctx, suite.Client, filePath, 0, false); err != nil {
+		t.Fatalf("GetDiagnosticsForFile failed: %v", err)
+	}
+
+	t.Run("AllFiles", func(t *testing.T) {
+		result, err := tools.GetWorkspaceDiagnostics(ctx, suite.Client, tools.WorkspaceDiagnosticsOptions{})
+		if err != nil {
+			t.Fatalf("GetWorkspaceDiagnostics failed: %v", err)
+		}
+
+		if !strings.Contains(result, "main.go: ") || !strings.Contains(result, "ERROR") {
+			t.Errorf("Expected errors in main.go but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "workspace_diagnostics", "all", result)
+	})
+
+	t.Run("PathGlob", func(t *testing.T) {
+		result, err := tools.GetWorkspaceDiagnostics(ctx, suite.Client, tools.WorkspaceDiagnosticsOptions{
+			PathGlob: "clean.go",
+		})
+		if err != nil {
+			t.Fatalf("GetWorkspaceDiagnostics failed: %v", err)
+		}
+
+		if !strings.Contains(result, "No diagnostics found in the workspace matching the filters") {
+			t.Errorf("Expected no diagnostics in clean.go but got: %s", result)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		result, err := tools.GetWorkspaceDiagnostics(ctx, suite.Client, tools.WorkspaceDiagnosticsOptions{
+			Limit: 1,
+		})
+		if err != nil {
+			t.Fatalf("GetWorkspaceDiagnostics failed: %v", err)
+		}
+
+		// Files sort by path, so go.mod is listed and main.go is left out
+		if strings.Contains(result, "main.go: ") || !strings.Contains(result, "1 more files not shown") {
+			t.Errorf("Expected files past the limit to be left out but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "workspace_diagnostics", "limit", result)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		result, err := tools.GetWorkspaceDiagnostics(ctx, suite.Client, tools.WorkspaceDiagnosticsOptions{
+			MinSeverity: "error",
+			Summary:     true,
+		})
+		if err != nil {
+			t.Fatalf("GetWorkspaceDiagnostics failed: %v", err)
+		}
+
+		if strings.Contains(result, "ERROR at") {
+			t.Errorf("Expected only counts in summary mode but got: %s", result)
+		}
+
+		common.SnapshotTest(t, "go", "workspace_diagnostics", "summary", result)
+	})
+}
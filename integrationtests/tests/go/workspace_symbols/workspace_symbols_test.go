@@ -0,0 +1,83 @@
+package workspace_symbols_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/go/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestWorkspaceSymbols tests the SearchWorkspaceSymbols tool with partial names and filters
+func TestWorkspaceSymbols(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	// Keep fuzzy matches from the standard library out of the results, they
+	// change between Go versions
+	workspaceGlob := filepath.Join(suite.WorkspaceDir, "**")
+
+	tests := []struct {
+		name         string
+		query        string
+		opts         tools.WorkspaceSymbolsOptions
+		expectedText string
+		snapshotName string
+	}{
+		{
+			name:         "Partial name",
+			query:        "Shared",
+			opts:         tools.WorkspaceSymbolsOptions{PathGlob: workspaceGlob},
+			expectedText: "SharedStruct [Struct]",
+			snapshotName: "partial",
+		},
+		{
+			name:         "Kind filter",
+			query:        "Shared",
+			opts:         tools.WorkspaceSymbolsOptions{Kinds: []string{"interface"}, PathGlob: workspaceGlob},
+			expectedText: "SharedInterface [Interface]",
+			snapshotName: "kind-filter",
+		},
+		{
+			name:         "Path filter",
+			query:        "Function",
+			opts:         tools.WorkspaceSymbolsOptions{PathGlob: "**/helper.go"},
+			expectedText: "HelperFunction [Function]",
+			snapshotName: "path-filter",
+		},
+		{
+			name:         "Pagination",
+			query:        "Shared",
+			opts:         tools.WorkspaceSymbolsOptions{Limit: 1, PathGlob: workspaceGlob},
+			expectedText: "Use offset 1 to see the next page",
+			snapshotName: "pagination",
+		},
+		{
+			name:         "Not found",
+			query:        "NotARealSymbolAnywhere",
+			expectedText: "No symbols found",
+			snapshotName: "not-found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tools.SearchWorkspaceSymbols(ctx, suite.Client, tc.query, tc.opts)
+			if err != nil {
+				t.Fatalf("Failed to search workspace symbols: %v", err)
+			}
+
+			if !strings.Contains(result, tc.expectedText) {
+				t.Errorf("Workspace symbols do not contain expected text: %s\nGot: %s", tc.expectedText, result)
+			}
+
+			common.SnapshotTest(t, "go", "workspace_symbols", tc.snapshotName, result)
+		})
+	}
+}
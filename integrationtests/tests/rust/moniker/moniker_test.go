@@ -0,0 +1,74 @@
+package moniker_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/rust/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestMonikers tests the moniker tool with the Rust language server
+func TestMonikers(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	// Open all files to ensure rust-analyzer indexes everything
+	for _, file := range []string{"src/main.rs", "src/types.rs", "src/helper.rs", "src/consumer.rs"} {
+		err := suite.Client.OpenFile(ctx, filepath.Join(suite.WorkspaceDir, file))
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file, err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		symbolName   string
+		file         string
+		line         int
+		column       int
+		expectedText string
+		snapshotName string
+	}{
+		{
+			name:         "ByName",
+			symbolName:   "helper_function",
+			expectedText: "Identifier: test_workspace::helper::helper_function",
+			snapshotName: "helper-function",
+		},
+		{
+			name:         "ByPosition",
+			file:         "src/types.rs",
+			line:         61,
+			column:       12,
+			expectedText: "Identifier: test_workspace::types::SharedStruct::method",
+			snapshotName: "struct-method",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := ""
+			if tc.file != "" {
+				filePath = filepath.Join(suite.WorkspaceDir, tc.file)
+			}
+
+			result, err := tools.GetMonikers(ctx, suite.Client, tc.symbolName, filePath, tc.line, tc.column)
+			if err != nil {
+				t.Fatalf("GetMonikers failed: %v", err)
+			}
+
+			if !strings.Contains(result, tc.expectedText) {
+				t.Errorf("Expected monikers to contain %q but got: %s", tc.expectedText, result)
+			}
+
+			common.SnapshotTest(t, "rust", "moniker", tc.snapshotName, result)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package move_file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/common"
+	"github.com/isaacphi/mcp-language-server/integrationtests/tests/typescript/internal"
+	"github.com/isaacphi/mcp-language-server/internal/tools"
+)
+
+// TestMoveFile tests that moving a file updates imports with the TypeScript language server
+func TestMoveFile(t *testing.T) {
+	suite := internal.GetTestSuite(t)
+
+	ctx, cancel := context.WithTimeout(suite.Context, 10*time.Second)
+	defer cancel()
+
+	// Open the files so that the TypeScript server knows about the imports
+	for _, file := range []string{"helper.ts", "consumer.ts", "another_consumer.ts"} {
+		if err := suite.Client.OpenFile(ctx, filepath.Join(suite.WorkspaceDir, file)); err != nil {
+			t.Fatalf("Failed to open %s: %v", file, err)
+		}
+	}
+	time.Sleep(3 * time.Second)
+
+	oldPath := filepath.Join(suite.WorkspaceDir, "helper.ts")
+	newPath := filepath.Join(suite.WorkspaceDir, "lib", "helper.ts")
+	result, err := tools.MoveFile(ctx, suite.Client, oldPath, newPath)
+	if err != nil {
+		t.Fatalf("MoveFile failed: %v", err)
+	}
+
+	if _, err := os.Stat(newPath); err != nil {
+		t.Errorf("Expected %s to exist after the move: %v", newPath, err)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be gone after the move", oldPath)
+	}
+	if !suite.Client.IsFileOpen(newPath) || suite.Client.IsFileOpen(oldPath) {
+		t.Errorf("Expected the open document to follow the move")
+	}
+
+	consumerContent, err := suite.ReadFile("consumer.ts")
+	if err != nil {
+		t.Fatalf("Failed to read consumer.ts: %v", err)
+	}
+	if !strings.Contains(consumerContent, "from './lib/helper'") {
+		t.Errorf("Expected the import in consumer.ts to be updated but got: %s", consumerContent)
+	}
+
+	// The first line names both paths, which snapshots can't normalize
+	_, details, _ := strings.Cut(result, "\n\n")
+	common.SnapshotTest(t, "typescript", "move_file", "helper", details)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -37,13 +38,33 @@ type Client struct {
 	notificationHandlers map[string]NotificationHandler
 	notificationMu       sync.RWMutex
 
+	// Notifications waiting to be handled, in the order they were received.
+	// notificationsReady is signalled when one is queued or the queue closes.
+	notifications       []func()
+	notificationsClosed bool
+	notificationsReady  chan struct{}
+	notificationsMu     sync.Mutex
+
 	// Diagnostic cache
 	diagnostics   map[protocol.DocumentUri][]protocol.Diagnostic
 	diagnosticsMu sync.RWMutex
 
+	// Channels closed the next time diagnostics are published for a document
+	diagnosticWaiters map[protocol.DocumentUri][]chan struct{}
+
+	// The active capture of the edits and messages the server sends. Servers
+	// don't say which request these belong to, so captures take turns, and
+	// captureSlot holds a value while one is active.
+	capture     *ServerEffects
+	captureMu   sync.Mutex
+	captureSlot chan struct{}
+
 	// Files are currently opened by the LSP
 	openFiles   map[string]*OpenFileInfo
 	openFilesMu sync.RWMutex
+
+	// Capabilities reported by the server when it was initialized
+	serverCapabilities protocol.ServerCapabilities
 }
 
 func NewClient(command string, args ...string) (*Client, error) {
@@ -73,8 +94,11 @@ func NewClient(command string, args ...string) (*Client, error) {
 		stderr:                stderr,
 		handlers:              make(map[string]chan *Message),
 		notificationHandlers:  make(map[string]NotificationHandler),
+		notificationsReady:    make(chan struct{}, 1),
 		serverRequestHandlers: make(map[string]ServerRequestHandler),
 		diagnostics:           make(map[protocol.DocumentUri][]protocol.Diagnostic),
+		diagnosticWaiters:     make(map[protocol.DocumentUri][]chan struct{}),
+		captureSlot:           make(chan struct{}, 1),
 		openFiles:             make(map[string]*OpenFileInfo),
 	}
 
@@ -97,6 +121,7 @@ func NewClient(command string, args ...string) (*Client, error) {
 
 	// Start message handling loop
 	go client.handleMessages()
+	go client.dispatchNotifications()
 
 	return client, nil
 }
@@ -142,6 +167,22 @@ func (c *Client) InitializeLSPClient(ctx context.Context, workspaceDir string) (
 						DynamicRegistration:    true,
 						RelativePatternSupport: true,
 					},
+					FileOperations: &protocol.FileOperationClientCapabilities{
+						DidCreate:  true,
+						WillCreate: true,
+						DidRename:  true,
+						WillRename: true,
+						DidDelete:  true,
+						WillDelete: true,
+					},
+					Symbol: &protocol.WorkspaceSymbolClientCapabilities{
+						TagSupport: &protocol.ClientSymbolTagOptions{
+							ValueSet: []protocol.SymbolTag{protocol.DeprecatedSymbol},
+						},
+						ResolveSupport: &protocol.ClientSymbolResolveOptions{
+							Properties: []string{"location.range"},
+						},
+					},
 				},
 				TextDocument: protocol.TextDocumentClientCapabilities{
 					Synchronization: &protocol.TextDocumentSyncClientCapabilities{
@@ -149,33 +190,94 @@ func (c *Client) InitializeLSPClient(ctx context.Context, workspaceDir string) (
 						DidSave:             true,
 					},
 					Completion: protocol.CompletionClientCapabilities{
-						CompletionItem: protocol.ClientCompletionItemOptions{},
+						CompletionItem: protocol.ClientCompletionItemOptions{
+							DocumentationFormat: []protocol.MarkupKind{protocol.Markdown, protocol.PlainText},
+							DeprecatedSupport:   true,
+							TagSupport: &protocol.CompletionItemTagOptions{
+								ValueSet: []protocol.CompletionItemTag{protocol.ComplDeprecated},
+							},
+							ResolveSupport: &protocol.ClientCompletionItemResolveOptions{
+								Properties: []string{"detail", "documentation"},
+							},
+							LabelDetailsSupport: true,
+						},
+						CompletionItemKind: &protocol.ClientCompletionItemOptionsKind{
+							ValueSet: completionItemKinds(),
+						},
+						ContextSupport: true,
+					},
+					SignatureHelp: &protocol.SignatureHelpClientCapabilities{
+						SignatureInformation: &protocol.ClientSignatureInformationOptions{
+							DocumentationFormat: []protocol.MarkupKind{protocol.Markdown, protocol.PlainText},
+							ParameterInformation: &protocol.ClientSignatureParameterInformationOptions{
+								LabelOffsetSupport: true,
+							},
+							ActiveParameterSupport: true,
+						},
+					},
+					InlayHint: &protocol.InlayHintClientCapabilities{
+						ResolveSupport: &protocol.ClientInlayHintResolveOptions{
+							Properties: []string{"tooltip", "label.tooltip", "label.location"},
+						},
+					},
+					DocumentHighlight: &protocol.DocumentHighlightClientCapabilities{},
+					Moniker:           &protocol.MonikerClientCapabilities{},
+					Rename: &protocol.RenameClientCapabilities{
+						PrepareSupport: true,
 					},
 					CodeLens: &protocol.CodeLensClientCapabilities{
 						DynamicRegistration: true,
 					},
-					DocumentSymbol: protocol.DocumentSymbolClientCapabilities{},
+					DocumentSymbol: protocol.DocumentSymbolClientCapabilities{
+						HierarchicalDocumentSymbolSupport: true,
+						TagSupport: &protocol.ClientSymbolTagOptions{
+							ValueSet: []protocol.SymbolTag{protocol.DeprecatedSymbol},
+						},
+					},
 					CodeAction: protocol.CodeActionClientCapabilities{
 						CodeActionLiteralSupport: protocol.ClientCodeActionLiteralOptions{
 							CodeActionKind: protocol.ClientCodeActionKindOptions{
-								ValueSet: []protocol.CodeActionKind{},
+								ValueSet: []protocol.CodeActionKind{
+									protocol.Empty,
+									protocol.QuickFix,
+									protocol.Refactor,
+									protocol.RefactorExtract,
+									protocol.RefactorInline,
+									protocol.RefactorMove,
+									protocol.RefactorRewrite,
+									protocol.Source,
+									protocol.SourceOrganizeImports,
+									protocol.SourceFixAll,
+								},
 							},
 						},
+						IsPreferredSupport: true,
+						DisabledSupport:    true,
+						DataSupport:        true,
+						ResolveSupport: &protocol.ClientCodeActionResolveOptions{
+							Properties: []string{"edit"},
+						},
+					},
+					Formatting: &protocol.DocumentFormattingClientCapabilities{},
+					RangeFormatting: &protocol.DocumentRangeFormattingClientCapabilities{
+						RangesSupport: true,
 					},
 					PublishDiagnostics: protocol.PublishDiagnosticsClientCapabilities{
 						VersionSupport: true,
 					},
 					SemanticTokens: protocol.SemanticTokensClientCapabilities{
 						Requests: protocol.ClientSemanticTokensRequestOptions{
-							Range: &protocol.Or_ClientSemanticTokensRequestOptions_range{},
-							Full:  &protocol.Or_ClientSemanticTokensRequestOptions_full{},
+							Range: &protocol.Or_ClientSemanticTokensRequestOptions_range{Value: true},
+							Full:  &protocol.Or_ClientSemanticTokensRequestOptions_full{Value: true},
 						},
-						TokenTypes:     []string{},
-						TokenModifiers: []string{},
-						Formats:        []protocol.TokenFormat{},
+						TokenTypes:     semanticTokenTypes(),
+						TokenModifiers: semanticTokenModifiers(),
+						Formats:        []protocol.TokenFormat{protocol.Relative},
 					},
 				},
-				Window: protocol.WindowClientCapabilities{},
+				Window: protocol.WindowClientCapabilities{
+					WorkDoneProgress: true,
+				},
 			},
 			InitializationOptions: map[string]any{
 				"codelenses": map[string]bool{
@@ -187,6 +289,18 @@ func (c *Client) InitializeLSPClient(ctx context.Context, workspaceDir string) (
 					"vendor":             true,
 					"vulncheck":          false,
 				},
+				// gopls only provides semantic tokens when enabled
+				"semanticTokens": true,
+				// gopls only provides inlay hints that are enabled
+				"hints": map[string]bool{
+					"assignVariableTypes":    true,
+					"compositeLiteralFields": true,
+					"compositeLiteralTypes":  true,
+					"constantValues":         true,
+					"functionTypeParameters": true,
+					"parameterNames":         true,
+					"rangeVariableTypes":     true,
+				},
 			},
 		},
 	}
@@ -195,16 +309,22 @@ func (c *Client) InitializeLSPClient(ctx context.Context, workspaceDir string) (
 	if err := c.Call(ctx, "initialize", initParams, &result); err != nil {
 		return nil, fmt.Errorf("initialize failed: %w", err)
 	}
+	c.serverCapabilities = result.Capabilities
 
 	if err := c.Initialized(ctx, protocol.InitializedParams{}); err != nil {
 		return nil, fmt.Errorf("initialized failed: %w", err)
 	}
 
 	// Register handlers
-	c.RegisterServerRequestHandler("workspace/applyEdit", HandleApplyEdit)
+	c.RegisterServerRequestHandler("workspace/applyEdit",
+		func(params json.RawMessage) (any, error) { return HandleApplyEdit(c, params) })
 	c.RegisterServerRequestHandler("workspace/configuration", HandleWorkspaceConfiguration)
 	c.RegisterServerRequestHandler("client/registerCapability", HandleRegisterCapability)
-	c.RegisterNotificationHandler("window/showMessage", HandleServerMessage)
+	c.RegisterServerRequestHandler("window/workDoneProgress/create", HandleWorkDoneProgressCreate)
+	c.RegisterNotificationHandler("window/showMessage",
+		func(params json.RawMessage) { HandleServerMessage(c, params) })
+	c.RegisterNotificationHandler("$/progress",
+		func(params json.RawMessage) { HandleProgress(c, params) })
 	c.RegisterNotificationHandler("textDocument/publishDiagnostics",
 		func(params json.RawMessage) { HandleDiagnostics(c, params) })
 
@@ -269,6 +389,54 @@ const (
 	StateError
 )
 
+// completionItemKinds returns every completion item kind, so servers don't
+// fall back to the basic kinds
+func completionItemKinds() []protocol.CompletionItemKind {
+	kinds := make([]protocol.CompletionItemKind, 0, len(protocol.CompletionItemKindMap))
+	for kind := protocol.TextCompletion; kind <= protocol.TypeParameterCompletion; kind++ {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// semanticTokenTypes returns the token types defined by the specification.
+// Servers use their own legend, but some only send the types a client lists.
+func semanticTokenTypes() []string {
+	types := []protocol.SemanticTokenTypes{
+		protocol.NamespaceType, protocol.TypeType, protocol.ClassType, protocol.EnumType,
+		protocol.InterfaceType, protocol.StructType, protocol.TypeParameterType, protocol.ParameterType,
+		protocol.VariableType, protocol.PropertyType, protocol.EnumMemberType, protocol.EventType,
+		protocol.FunctionType, protocol.MethodType, protocol.MacroType, protocol.KeywordType,
+		protocol.ModifierType, protocol.CommentType, protocol.StringType, protocol.NumberType,
+		protocol.RegexpType, protocol.OperatorType, protocol.DecoratorType, protocol.LabelType,
+	}
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = string(t)
+	}
+	return result
+}
+
+// semanticTokenModifiers returns the token modifiers defined by the specification
+func semanticTokenModifiers() []string {
+	modifiers := []protocol.SemanticTokenModifiers{
+		protocol.ModDeclaration, protocol.ModDefinition, protocol.ModReadonly, protocol.ModStatic,
+		protocol.ModDeprecated, protocol.ModAbstract, protocol.ModAsync, protocol.ModModification,
+		protocol.ModDocumentation, protocol.ModDefaultLibrary,
+	}
+	result := make([]string, len(modifiers))
+	for i, m := range modifiers {
+		result[i] = string(m)
+	}
+	return result
+}
+
+// ServerCapabilities returns the capabilities the server reported in its
+// initialize result
+func (c *Client) ServerCapabilities() protocol.ServerCapabilities {
+	return c.serverCapabilities
+}
+
 func (c *Client) WaitForServerReady(ctx context.Context) error {
 	// TODO: wait for specific messages or poll workspace/symbol
 	time.Sleep(time.Second * 1)
@@ -395,6 +563,53 @@ func (c *Client) IsFileOpen(filepath string) bool {
 	return exists
 }
 
+// RenameOpenFiles updates open documents after oldPath, a file or a directory,
+// was renamed to newPath on disk. Each open document under oldPath is closed
+// and opened again under its new URI, so that the server doesn't keep a stale
+// copy of it.
+func (c *Client) RenameOpenFiles(ctx context.Context, oldPath, newPath string) error {
+	renamed := c.openFilesUnder(oldPath)
+	for _, filePath := range renamed {
+		if err := c.CloseFile(ctx, filePath); err != nil {
+			return err
+		}
+		if err := c.OpenFile(ctx, newPath+strings.TrimPrefix(filePath, oldPath)); err != nil {
+			return err
+		}
+	}
+
+	lspLogger.Debug("Renamed %d open files from %s to %s", len(renamed), oldPath, newPath)
+
+	return nil
+}
+
+// CloseFilesUnder closes the open documents for path and, if it is a
+// directory, the files in it
+func (c *Client) CloseFilesUnder(ctx context.Context, path string) error {
+	for _, filePath := range c.openFilesUnder(path) {
+		if err := c.CloseFile(ctx, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openFilesUnder returns the paths of the open documents for path and, if it
+// is a directory, the files in it
+func (c *Client) openFilesUnder(path string) []string {
+	c.openFilesMu.RLock()
+	defer c.openFilesMu.RUnlock()
+
+	var paths []string
+	for uri := range c.openFiles {
+		filePath := strings.TrimPrefix(uri, "file://")
+		if filePath == path || strings.HasPrefix(filePath, path+"/") {
+			paths = append(paths, filePath)
+		}
+	}
+	return paths
+}
+
 // CloseAllFiles closes all currently open files
 func (c *Client) CloseAllFiles(ctx context.Context) {
 	c.openFilesMu.Lock()
@@ -425,3 +640,59 @@ func (c *Client) GetFileDiagnostics(uri protocol.DocumentUri) []protocol.Diagnos
 
 	return c.diagnostics[uri]
 }
+
+// DiagnosticsPublished returns a channel that is closed the next time the
+// server publishes diagnostics for uri. Servers publish diagnostics once they
+// have processed a document, so this can be used to wait for a file to be
+// ready after opening or changing it. Call the returned function when done
+// waiting, so that the channel is released if it was never closed.
+func (c *Client) DiagnosticsPublished(uri protocol.DocumentUri) (<-chan struct{}, func()) {
+	c.diagnosticsMu.Lock()
+	defer c.diagnosticsMu.Unlock()
+
+	ch := make(chan struct{})
+	c.diagnosticWaiters[uri] = append(c.diagnosticWaiters[uri], ch)
+
+	cancel := func() {
+		c.diagnosticsMu.Lock()
+		defer c.diagnosticsMu.Unlock()
+
+		waiters := slices.DeleteFunc(c.diagnosticWaiters[uri], func(waiter chan struct{}) bool {
+			return waiter == ch
+		})
+		if len(waiters) == 0 {
+			delete(c.diagnosticWaiters, uri)
+		} else {
+			c.diagnosticWaiters[uri] = waiters
+		}
+	}
+	return ch, cancel
+}
+
+// GetAllDiagnostics returns a copy of the diagnostics the server has published
+// for every file
+func (c *Client) GetAllDiagnostics() map[protocol.DocumentUri][]protocol.Diagnostic {
+	c.diagnosticsMu.RLock()
+	defer c.diagnosticsMu.RUnlock()
+
+	diagnostics := make(map[protocol.DocumentUri][]protocol.Diagnostic, len(c.diagnostics))
+	for uri, fileDiagnostics := range c.diagnostics {
+		diagnostics[uri] = fileDiagnostics
+	}
+	return diagnostics
+}
+
+// ClearDiagnostics removes the cached diagnostics for path and, if it is a
+// directory, the files in it. Servers don't always clear the diagnostics of
+// deleted files themselves.
+func (c *Client) ClearDiagnostics(path string) {
+	c.diagnosticsMu.Lock()
+	defer c.diagnosticsMu.Unlock()
+
+	for uri := range c.diagnostics {
+		filePath := strings.TrimPrefix(string(uri), "file://")
+		if filePath == path || strings.HasPrefix(filePath, path+"/") {
+			delete(c.diagnostics, uri)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// AppliedEdit is a workspace edit the server asked the client to apply with
+// workspace/applyEdit
+type AppliedEdit struct {
+	Label         string
+	Edit          protocol.WorkspaceEdit
+	Applied       bool
+	FailureReason string
+}
+
+// ServerEffects records what the server does to the workspace while it is
+// being captured: the edits it applies and the messages it shows. It also
+// tracks the work done progress reported with its token, so callers can wait
+// for a command that the server runs in the background.
+type ServerEffects struct {
+	mu       sync.Mutex
+	edits    []AppliedEdit
+	messages []protocol.ShowMessageParams
+
+	// The progress token of the captured request, whether the server began
+	// reporting progress with it and whether that has ended
+	token string
+	begun bool
+	ended bool
+	// Closed and replaced whenever progress is recorded
+	progressChanged chan struct{}
+}
+
+// CaptureServerEffects starts recording the server's edits and messages, and
+// the progress it reports with token. Servers don't say which request edits
+// and messages belong to, so only one capture is active at a time and this
+// waits for the previous one to stop. Call StopCapture when done.
+func (c *Client) CaptureServerEffects(ctx context.Context, token protocol.ProgressToken) (*ServerEffects, error) {
+	select {
+	case c.captureSlot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	effects := &ServerEffects{
+		token:           progressTokenKey(token),
+		progressChanged: make(chan struct{}),
+	}
+
+	c.captureMu.Lock()
+	c.capture = effects
+	c.captureMu.Unlock()
+
+	return effects, nil
+}
+
+// StopCapture stops recording to effects, so that the next capture can start
+func (c *Client) StopCapture(effects *ServerEffects) {
+	c.captureMu.Lock()
+	defer c.captureMu.Unlock()
+
+	if c.capture != effects {
+		return
+	}
+	c.capture = nil
+	<-c.captureSlot
+}
+
+// recordEffect calls record for the active capture, if any
+func (c *Client) recordEffect(record func(effects *ServerEffects)) {
+	c.captureMu.Lock()
+	defer c.captureMu.Unlock()
+
+	if c.capture == nil {
+		return
+	}
+	c.capture.mu.Lock()
+	record(c.capture)
+	c.capture.mu.Unlock()
+}
+
+// Edits returns the workspace edits the server applied during the capture
+func (e *ServerEffects) Edits() []AppliedEdit {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]AppliedEdit(nil), e.edits...)
+}
+
+// Messages returns the messages the server showed during the capture
+func (e *ServerEffects) Messages() []protocol.ShowMessageParams {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]protocol.ShowMessageParams(nil), e.messages...)
+}
+
+// WaitForProgress waits until the work reported with the capture's token has
+// ended. It returns immediately if the server never began reporting progress
+// for it, so call Client.WaitForNotifications first to see progress the server
+// began before responding to the request that carried the token.
+func (e *ServerEffects) WaitForProgress(ctx context.Context) error {
+	for {
+		e.mu.Lock()
+		begun, ended := e.begun, e.ended
+		changed := e.progressChanged
+		e.mu.Unlock()
+
+		if !begun || ended {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// recordProgress records progress reported with token, if it is the token of
+// the capture
+func (e *ServerEffects) recordProgress(token protocol.ProgressToken, kind string) {
+	if progressTokenKey(token) != e.token {
+		return
+	}
+
+	switch kind {
+	case "begin":
+		// Don't reopen work that has already ended
+		if e.begun {
+			return
+		}
+		e.begun = true
+	case "end":
+		e.ended = true
+	default:
+		return
+	}
+
+	close(e.progressChanged)
+	e.progressChanged = make(chan struct{})
+}
+
+// progressTokenKey returns a key for a token, which is either an integer or a
+// string
+func progressTokenKey(token protocol.ProgressToken) string {
+	return fmt.Sprint(token.Value)
+}
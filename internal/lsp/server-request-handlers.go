@@ -60,7 +60,7 @@ func HandleRegisterCapability(params json.RawMessage) (any, error) {
 	return nil, nil
 }
 
-func HandleApplyEdit(params json.RawMessage) (any, error) {
+func HandleApplyEdit(client *Client, params json.RawMessage) (any, error) {
 	var workspaceEdit protocol.ApplyWorkspaceEditParams
 	if err := json.Unmarshal(params, &workspaceEdit); err != nil {
 		return protocol.ApplyWorkspaceEditResult{Applied: false}, err
@@ -68,6 +68,16 @@ func HandleApplyEdit(params json.RawMessage) (any, error) {
 
 	// Apply the edits
 	err := utilities.ApplyWorkspaceEdit(workspaceEdit.Edit)
+
+	client.recordEffect(func(effects *ServerEffects) {
+		effects.edits = append(effects.edits, AppliedEdit{
+			Label:         workspaceEdit.Label,
+			Edit:          workspaceEdit.Edit,
+			Applied:       err == nil,
+			FailureReason: workspaceEditFailure(err),
+		})
+	})
+
 	if err != nil {
 		lspLogger.Error("Error applying workspace edit: %v", err)
 		return protocol.ApplyWorkspaceEditResult{
@@ -88,16 +98,26 @@ func workspaceEditFailure(err error) string {
 	return err.Error()
 }
 
+// HandleWorkDoneProgressCreate accepts progress tokens created by the server.
+// The progress itself is reported with $/progress notifications.
+func HandleWorkDoneProgressCreate(params json.RawMessage) (any, error) {
+	return nil, nil
+}
+
 // Notifications
 
 // HandleServerMessage processes window/showMessage notifications from the server
-func HandleServerMessage(params json.RawMessage) {
+func HandleServerMessage(client *Client, params json.RawMessage) {
 	var msg protocol.ShowMessageParams
 	if err := json.Unmarshal(params, &msg); err != nil {
 		lspLogger.Error("Error unmarshaling server message: %v", err)
 		return
 	}
 
+	client.recordEffect(func(effects *ServerEffects) {
+		effects.messages = append(effects.messages, msg)
+	})
+
 	// Log the message with appropriate level
 	switch msg.Type {
 	case protocol.Error:
@@ -111,6 +131,29 @@ func HandleServerMessage(params json.RawMessage) {
 	}
 }
 
+// HandleProgress processes $/progress notifications, recording when work
+// begins and ends
+func HandleProgress(client *Client, params json.RawMessage) {
+	var progress struct {
+		Token protocol.ProgressToken `json:"token"`
+		Value struct {
+			Kind    string `json:"kind"`
+			Title   string `json:"title"`
+			Message string `json:"message"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(params, &progress); err != nil {
+		lspLogger.Error("Error unmarshaling progress params: %v", err)
+		return
+	}
+
+	lspLogger.Debug("Progress %v %s: %s%s", progress.Token.Value, progress.Value.Kind, progress.Value.Title, progress.Value.Message)
+
+	client.recordEffect(func(effects *ServerEffects) {
+		effects.recordProgress(progress.Token, progress.Value.Kind)
+	})
+}
+
 // HandleDiagnostics processes textDocument/publishDiagnostics notifications
 func HandleDiagnostics(client *Client, params json.RawMessage) {
 	var diagParams protocol.PublishDiagnosticsParams
@@ -122,6 +165,10 @@ func HandleDiagnostics(client *Client, params json.RawMessage) {
 	// Save diagnostics in client
 	client.diagnosticsMu.Lock()
 	client.diagnostics[diagParams.URI] = diagParams.Diagnostics
+	for _, ch := range client.diagnosticWaiters[diagParams.URI] {
+		close(ch)
+	}
+	delete(client.diagnosticWaiters, diagParams.URI)
 	client.diagnosticsMu.Unlock()
 
 	lspLogger.Info("Received diagnostics for %s: %d items", diagParams.URI, len(diagParams.Diagnostics))
@@ -112,6 +112,7 @@ func (c *Client) handleMessages() {
 			} else {
 				lspLogger.Error("Error reading message: %v", err)
 			}
+			c.closeNotifications()
 			return
 		}
 
@@ -172,7 +173,8 @@ func (c *Client) handleMessages() {
 
 			if ok {
 				lspLogger.Debug("Handling notification: %s", msg.Method)
-				go handler(msg.Params)
+				params := msg.Params
+				c.enqueueNotification(func() { handler(params) })
 			} else {
 				lspLogger.Debug("No handler for notification: %s", msg.Method)
 			}
@@ -198,6 +200,81 @@ func (c *Client) handleMessages() {
 	}
 }
 
+// dispatchNotifications runs notification handlers one at a time, in the order
+// the notifications were received, so that e.g. newer diagnostics for a file
+// are never overwritten by older ones. Handlers don't block reading messages.
+func (c *Client) dispatchNotifications() {
+	for {
+		c.notificationsMu.Lock()
+		if len(c.notifications) == 0 {
+			closed := c.notificationsClosed
+			c.notificationsMu.Unlock()
+			if closed {
+				return
+			}
+			<-c.notificationsReady
+			continue
+		}
+		handle := c.notifications[0]
+		c.notifications[0] = nil
+		c.notifications = c.notifications[1:]
+		c.notificationsMu.Unlock()
+
+		handle()
+	}
+}
+
+// enqueueNotification queues handle to run after the notifications received
+// before it. The queue is unbounded, so that slow handlers never block reading
+// responses. It reports false if the connection has been closed.
+func (c *Client) enqueueNotification(handle func()) bool {
+	c.notificationsMu.Lock()
+	if c.notificationsClosed {
+		c.notificationsMu.Unlock()
+		return false
+	}
+	c.notifications = append(c.notifications, handle)
+	c.notificationsMu.Unlock()
+
+	c.signalNotifications()
+	return true
+}
+
+// closeNotifications stops accepting notifications. The ones already queued
+// are still handled.
+func (c *Client) closeNotifications() {
+	c.notificationsMu.Lock()
+	c.notificationsClosed = true
+	c.notificationsMu.Unlock()
+
+	c.signalNotifications()
+}
+
+// signalNotifications wakes up dispatchNotifications if it is waiting
+func (c *Client) signalNotifications() {
+	select {
+	case c.notificationsReady <- struct{}{}:
+	default:
+	}
+}
+
+// WaitForNotifications waits until the notifications received so far have
+// been handled. Servers may send notifications about a request, like progress,
+// before responding to it, so this makes sure they have been seen.
+func (c *Client) WaitForNotifications(ctx context.Context) error {
+	handled := make(chan struct{})
+	if !c.enqueueNotification(func() { close(handled) }) {
+		return nil
+	}
+
+	select {
+	case <-handled:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Call makes a request and waits for the response
 func (c *Client) Call(ctx context.Context, method string, params any, result any) error {
 	id := c.nextID.Add(1)
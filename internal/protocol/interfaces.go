@@ -1,15 +1,24 @@
 package protocol
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // TextEditResult is an interface for types that represent workspace symbols
 type WorkspaceSymbolResult interface {
 	GetName() string
 	GetLocation() Location
+	GetKind() SymbolKind
+	GetContainerName() string
+	GetTags() []SymbolTag
 	isWorkspaceSymbol() // marker method
 }
 
-func (ws *WorkspaceSymbol) GetName() string { return ws.Name }
+func (ws *WorkspaceSymbol) GetName() string          { return ws.Name }
+func (ws *WorkspaceSymbol) GetKind() SymbolKind      { return ws.Kind }
+func (ws *WorkspaceSymbol) GetContainerName() string { return ws.ContainerName }
+func (ws *WorkspaceSymbol) GetTags() []SymbolTag     { return ws.Tags }
 func (ws *WorkspaceSymbol) GetLocation() Location {
 	switch v := ws.Location.Value.(type) {
 	case Location:
@@ -21,9 +30,12 @@ func (ws *WorkspaceSymbol) GetLocation() Location {
 }
 func (ws *WorkspaceSymbol) isWorkspaceSymbol() {}
 
-func (si *SymbolInformation) GetName() string       { return si.Name }
-func (si *SymbolInformation) GetLocation() Location { return si.Location }
-func (si *SymbolInformation) isWorkspaceSymbol()    {}
+func (si *SymbolInformation) GetName() string          { return si.Name }
+func (si *SymbolInformation) GetLocation() Location    { return si.Location }
+func (si *SymbolInformation) GetKind() SymbolKind      { return si.Kind }
+func (si *SymbolInformation) GetContainerName() string { return si.ContainerName }
+func (si *SymbolInformation) GetTags() []SymbolTag     { return si.Tags }
+func (si *SymbolInformation) isWorkspaceSymbol()       {}
 
 // Results converts the Value to a slice of WorkspaceSymbolResult
 func (r Or_Result_workspace_symbol) Results() ([]WorkspaceSymbolResult, error) {
@@ -52,16 +64,18 @@ func (r Or_Result_workspace_symbol) Results() ([]WorkspaceSymbolResult, error) {
 type DocumentSymbolResult interface {
 	GetRange() Range
 	GetName() string
+	GetKind() SymbolKind
 	isDocumentSymbol() // marker method
 }
 
-func (ds *DocumentSymbol) GetRange() Range   { return ds.Range }
-func (ds *DocumentSymbol) GetName() string   { return ds.Name }
-func (ds *DocumentSymbol) isDocumentSymbol() {}
+func (ds *DocumentSymbol) GetRange() Range     { return ds.Range }
+func (ds *DocumentSymbol) GetName() string     { return ds.Name }
+func (ds *DocumentSymbol) GetKind() SymbolKind { return ds.Kind }
+func (ds *DocumentSymbol) isDocumentSymbol()   {}
 
 func (si *SymbolInformation) GetRange() Range { return si.Location.Range }
 
-// Note: SymbolInformation already has GetName() implemented above
+// Note: SymbolInformation already has GetName() and GetKind() implemented above
 func (si *SymbolInformation) isDocumentSymbol() {}
 
 // Results converts the Value to a slice of DocumentSymbolResult
@@ -115,3 +129,96 @@ func (e Or_TextDocumentEdit_edits_Elem) AsTextEdit() (TextEdit, error) {
 		return TextEdit{}, fmt.Errorf("unknown text edit type: %T", e.Value)
 	}
 }
+
+// Locations converts the Value to a slice of Locations. LocationLinks are
+// converted to the location of the target's selection range (usually its name).
+func (r Or_Result_textDocument_implementation) Locations() ([]Location, error) {
+	return definitionResultLocations(r.Value)
+}
+
+func (r Or_Result_textDocument_typeDefinition) Locations() ([]Location, error) {
+	return definitionResultLocations(r.Value)
+}
+
+func (r Or_Result_textDocument_declaration) Locations() ([]Location, error) {
+	return definitionResultLocations(r.Value)
+}
+
+func definitionResultLocations(value any) ([]Location, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case Definition:
+		return definitionResultLocations(v.Value)
+	case Declaration:
+		return definitionResultLocations(v.Value)
+	case Location:
+		return []Location{v}, nil
+	case []Location:
+		return v, nil
+	case []LocationLink:
+		locations := make([]Location, len(v))
+		for i, link := range v {
+			locations[i] = Location{
+				URI:   link.TargetURI,
+				Range: link.TargetSelectionRange,
+			}
+		}
+		return locations, nil
+	default:
+		return nil, fmt.Errorf("unknown location type: %T", value)
+	}
+}
+
+// Items returns the completion items of a completion result, which is either
+// a CompletionList or a plain []CompletionItem
+func (r Or_Result_textDocument_completion) Items() ([]CompletionItem, error) {
+	switch v := r.Value.(type) {
+	case nil:
+		return nil, nil
+	case CompletionList:
+		return v.Items, nil
+	case []CompletionItem:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unknown completion result type: %T", r.Value)
+	}
+}
+
+// UnmarshalJSON decodes parameter label offsets, which are sent as a
+// [start, end] array rather than the object the generated struct expects
+func (t *Tuple_ParameterInformation_label_Item1) UnmarshalJSON(x []byte) error {
+	var offsets [2]uint32
+	if err := json.Unmarshal(x, &offsets); err != nil {
+		return err
+	}
+	t.Fld0, t.Fld1 = offsets[0], offsets[1]
+	return nil
+}
+
+func (t Tuple_ParameterInformation_label_Item1) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]uint32{t.Fld0, t.Fld1})
+}
+
+// UnmarshalJSON accepts both forms of an inlay hint label, a plain string or a
+// list of label parts. The generated struct only supports label parts.
+func (h *InlayHint) UnmarshalJSON(x []byte) error {
+	type inlayHint InlayHint
+	var raw struct {
+		inlayHint
+		Label json.RawMessage `json:"label"`
+	}
+	if err := json.Unmarshal(x, &raw); err != nil {
+		return err
+	}
+
+	*h = InlayHint(raw.inlayHint)
+	h.Label = nil
+
+	var label string
+	if err := json.Unmarshal(raw.Label, &label); err == nil {
+		h.Label = []InlayHintLabelPart{{Value: label}}
+		return nil
+	}
+	return json.Unmarshal(raw.Label, &h.Label)
+}
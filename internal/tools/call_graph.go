@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// callGraphNode is a function in a call graph, identified by where it is
+// declared
+type callGraphNode struct {
+	ID   string
+	Item protocol.CallHierarchyItem
+}
+
+// callGraphEdge is a call from one function to another
+type callGraphEdge struct {
+	From, To  string
+	CallSites []protocol.Range
+}
+
+// callGraph flattens call hierarchy trees into nodes and edges, with the
+// edges always pointing from the caller to the callee
+type callGraph struct {
+	Nodes  []callGraphNode
+	Edges  []callGraphEdge
+	Errors []string
+	// Truncated lists the nodes whose calls were cut off by the node budget
+	Truncated []string
+
+	ids   map[string]string
+	edges map[[2]string]int
+}
+
+func newCallGraph(sections []callHierarchySection, incoming bool) *callGraph {
+	graph := &callGraph{
+		ids:   make(map[string]string),
+		edges: make(map[[2]string]int),
+	}
+
+	var walk func(node *callTreeNode, id string)
+	walk = func(node *callTreeNode, id string) {
+		if node.Err != nil {
+			graph.Errors = append(graph.Errors, fmt.Sprintf("%s: %v", node.Item.Name, node.Err))
+		}
+		if node.Truncated && !slices.Contains(graph.Truncated, id) {
+			graph.Truncated = append(graph.Truncated, id)
+		}
+		for _, child := range node.Children {
+			childID := graph.addNode(child.Item)
+			if incoming {
+				graph.addEdge(childID, id, child.CallSites)
+			} else {
+				graph.addEdge(id, childID, child.CallSites)
+			}
+			walk(child, childID)
+		}
+	}
+
+	for _, section := range sections {
+		if section.Err != nil {
+			graph.Errors = append(graph.Errors, fmt.Sprintf("%s: %v", section.Name, section.Err))
+		}
+		for _, root := range section.Roots {
+			walk(root, graph.addNode(root.Item))
+		}
+	}
+
+	return graph
+}
+
+func (g *callGraph) addNode(item protocol.CallHierarchyItem) string {
+	key := callHierarchyItemKey(item)
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+	id := fmt.Sprintf("n%d", len(g.Nodes))
+	g.ids[key] = id
+	g.Nodes = append(g.Nodes, callGraphNode{ID: id, Item: item})
+	return id
+}
+
+func (g *callGraph) addEdge(from, to string, callSites []protocol.Range) {
+	key := [2]string{from, to}
+	if index, ok := g.edges[key]; ok {
+		g.Edges[index].CallSites = append(g.Edges[index].CallSites, callSites...)
+		return
+	}
+	g.edges[key] = len(g.Edges)
+	g.Edges = append(g.Edges, callGraphEdge{From: from, To: to, CallSites: callSites})
+}
+
+// callGraphNodeLabel labels a node with its name and where it is declared
+func callGraphNodeLabel(item protocol.CallHierarchyItem) string {
+	return fmt.Sprintf("%s\n%s:%d", item.Name,
+		filepath.Base(strings.TrimPrefix(string(item.URI), "file://")), item.SelectionRange.Start.Line+1)
+}
+
+// callGraphTruncatedLabel labels the placeholder for calls that were left out
+var callGraphTruncatedLabel = fmt.Sprintf("More calls not shown\n(limit of %d functions)", maxCallHierarchyNodes)
+
+// callGraphEdgeLabel labels an edge with the lines of its call sites
+func callGraphEdgeLabel(edge callGraphEdge) string {
+	lines := make([]string, 0, len(edge.CallSites))
+	for _, callSite := range edge.CallSites {
+		lines = append(lines, fmt.Sprintf("L%d", callSite.Start.Line+1))
+	}
+	return strings.Join(lines, ", ")
+}
+
+// formatCallGraphMermaid formats call hierarchies as a Mermaid flowchart
+func formatCallGraphMermaid(sections []callHierarchySection, incoming bool) string {
+	graph := newCallGraph(sections, incoming)
+
+	escape := func(text string) string {
+		text = strings.ReplaceAll(text, `"`, "#quot;")
+		return strings.ReplaceAll(text, "\n", "<br/>")
+	}
+
+	var result strings.Builder
+	result.WriteString("flowchart LR\n")
+	for _, message := range graph.Errors {
+		fmt.Fprintf(&result, "  %%%% Error: %s\n", strings.ReplaceAll(message, "\n", " "))
+	}
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&result, "  %s[\"%s\"]\n", node.ID, escape(callGraphNodeLabel(node.Item)))
+	}
+	for _, edge := range graph.Edges {
+		if label := callGraphEdgeLabel(edge); label != "" {
+			fmt.Fprintf(&result, "  %s -->|\"%s\"| %s\n", edge.From, escape(label), edge.To)
+		} else {
+			fmt.Fprintf(&result, "  %s --> %s\n", edge.From, edge.To)
+		}
+	}
+	for _, id := range graph.Truncated {
+		fmt.Fprintf(&result, "  %s_more[\"%s\"]\n", id, escape(callGraphTruncatedLabel))
+		if incoming {
+			fmt.Fprintf(&result, "  %s_more -.-> %s\n", id, id)
+		} else {
+			fmt.Fprintf(&result, "  %s -.-> %s_more\n", id, id)
+		}
+	}
+	return result.String()
+}
+
+// formatCallGraphDOT formats call hierarchies as a Graphviz DOT digraph
+func formatCallGraphDOT(sections []callHierarchySection, incoming bool) string {
+	graph := newCallGraph(sections, incoming)
+
+	escape := func(text string) string {
+		text = strings.ReplaceAll(text, `\`, `\\`)
+		text = strings.ReplaceAll(text, `"`, `\"`)
+		return strings.ReplaceAll(text, "\n", `\n`)
+	}
+
+	var result strings.Builder
+	result.WriteString("digraph calls {\n")
+	result.WriteString("  rankdir=LR;\n")
+	result.WriteString("  node [shape=box];\n")
+	for _, message := range graph.Errors {
+		fmt.Fprintf(&result, "  // Error: %s\n", strings.ReplaceAll(message, "\n", " "))
+	}
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&result, "  %s [label=\"%s\"];\n", node.ID, escape(callGraphNodeLabel(node.Item)))
+	}
+	for _, edge := range graph.Edges {
+		if label := callGraphEdgeLabel(edge); label != "" {
+			fmt.Fprintf(&result, "  %s -> %s [label=\"%s\"];\n", edge.From, edge.To, escape(label))
+		} else {
+			fmt.Fprintf(&result, "  %s -> %s;\n", edge.From, edge.To)
+		}
+	}
+	for _, id := range graph.Truncated {
+		fmt.Fprintf(&result, "  %s_more [label=\"%s\", shape=plaintext];\n", id, escape(callGraphTruncatedLabel))
+		if incoming {
+			fmt.Fprintf(&result, "  %s_more -> %s [style=dashed];\n", id, id)
+		} else {
+			fmt.Fprintf(&result, "  %s -> %s_more [style=dashed];\n", id, id)
+		}
+	}
+	result.WriteString("}\n")
+	return result.String()
+}
+
+// callTreeJSON is a function in the JSON output of a call hierarchy
+type callTreeJSON struct {
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Detail    string          `json:"detail,omitempty"`
+	File      string          `json:"file"`
+	Line      uint32          `json:"line"`
+	Column    uint32          `json:"column"`
+	CallSites []callSiteJSON  `json:"callSites,omitempty"`
+	Callers   []*callTreeJSON `json:"callers,omitempty"`
+	Callees   []*callTreeJSON `json:"callees,omitempty"`
+	Repeated  bool            `json:"repeated,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// callSiteJSON is the 1-indexed position of a call in the caller's file
+type callSiteJSON struct {
+	File   string `json:"file"`
+	Line   uint32 `json:"line"`
+	Column uint32 `json:"column"`
+}
+
+// formatCallGraphJSON formats call hierarchies as nested JSON objects
+func formatCallGraphJSON(sections []callHierarchySection, incoming bool, maxDepth int) (string, error) {
+	var convert func(node *callTreeNode, parent *callTreeNode) *callTreeJSON
+	convert = func(node *callTreeNode, parent *callTreeNode) *callTreeJSON {
+		item := node.Item
+		result := &callTreeJSON{
+			Name:      item.Name,
+			Kind:      protocol.TableKindMap[item.Kind],
+			Detail:    item.Detail,
+			File:      strings.TrimPrefix(string(item.URI), "file://"),
+			Line:      item.SelectionRange.Start.Line + 1,
+			Column:    item.SelectionRange.Start.Character + 1,
+			Repeated:  node.Repeated,
+			Truncated: node.Truncated,
+		}
+		if node.Err != nil {
+			result.Error = node.Err.Error()
+		}
+
+		if parent != nil {
+			// Call sites are in the caller's file
+			caller := parent.Item
+			if incoming {
+				caller = item
+			}
+			for _, callSite := range node.CallSites {
+				result.CallSites = append(result.CallSites, callSiteJSON{
+					File:   strings.TrimPrefix(string(caller.URI), "file://"),
+					Line:   callSite.Start.Line + 1,
+					Column: callSite.Start.Character + 1,
+				})
+			}
+		}
+
+		for _, child := range node.Children {
+			if incoming {
+				result.Callers = append(result.Callers, convert(child, node))
+			} else {
+				result.Callees = append(result.Callees, convert(child, node))
+			}
+		}
+		return result
+	}
+
+	output := struct {
+		Direction string          `json:"direction"`
+		MaxDepth  int             `json:"maxDepth"`
+		Roots     []*callTreeJSON `json:"roots"`
+		Errors    []string        `json:"errors,omitempty"`
+	}{
+		Direction: "callees",
+		MaxDepth:  maxDepth,
+		Roots:     []*callTreeJSON{},
+	}
+	if incoming {
+		output.Direction = "callers"
+	}
+
+	for _, section := range sections {
+		if section.Err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", section.Name, section.Err))
+		}
+		for _, root := range section.Roots {
+			output.Roots = append(output.Roots, convert(root, nil))
+		}
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format call hierarchy as JSON: %v", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callItem(name, uri string, line uint32) protocol.CallHierarchyItem {
+	r := protocol.Range{
+		Start: protocol.Position{Line: line, Character: 5},
+		End:   protocol.Position{Line: line, Character: 5 + uint32(len(name))},
+	}
+	return protocol.CallHierarchyItem{Name: name, Kind: protocol.Function, URI: protocol.DocumentUri(uri), Range: r, SelectionRange: r}
+}
+
+func callSite(line uint32) []protocol.Range {
+	return []protocol.Range{{Start: protocol.Position{Line: line, Character: 1}}}
+}
+
+// main calls FooBar, which calls helper, which recurses into FooBar
+func testCallSections() []callHierarchySection {
+	fooBar := callItem("FooBar", "file:///project/main.go", 5)
+	return []callHierarchySection{{
+		Name: "main",
+		Roots: []*callTreeNode{{
+			Item: callItem("main", "file:///project/main.go", 11),
+			Children: []*callTreeNode{{
+				Item:      fooBar,
+				CallSites: callSite(12),
+				Children: []*callTreeNode{{
+					Item:      callItem("helper", "file:///project/helper.go", 2),
+					CallSites: callSite(6),
+					Children: []*callTreeNode{{
+						Item:      fooBar,
+						CallSites: callSite(3),
+						Repeated:  true,
+					}},
+					Truncated: true,
+				}},
+			}},
+		}},
+	}}
+}
+
+func TestFormatCallTreeText(t *testing.T) {
+	assert.Equal(t, `
+---
+Name: main
+Detail: 
+File: /project/main.go
+Range: L12:C6 - L12:C10
+- Calls: FooBar
+  Detail: 
+  File: /project/main.go
+  Range: L6:C6 - L6:C12
+  - Calls: helper
+    Detail: 
+    File: /project/helper.go
+    Range: L3:C6 - L3:C12
+    - Calls: FooBar (shown elsewhere)
+    - More calls not shown, the limit of 100 functions was reached
+`, formatCallTreeText(testCallSections(), false))
+}
+
+func TestFormatCallGraphMermaid(t *testing.T) {
+	assert.Equal(t, `flowchart LR
+  n0["main<br/>main.go:12"]
+  n1["FooBar<br/>main.go:6"]
+  n2["helper<br/>helper.go:3"]
+  n0 -->|"L13"| n1
+  n1 -->|"L7"| n2
+  n2 -->|"L4"| n1
+  n2_more["More calls not shown<br/>(limit of 100 functions)"]
+  n2 -.-> n2_more
+`, formatCallGraphMermaid(testCallSections(), false))
+}
+
+func TestFormatCallGraphDOT(t *testing.T) {
+	// For callers, the children call their parents
+	assert.Equal(t, `digraph calls {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="main\nmain.go:12"];
+  n1 [label="FooBar\nmain.go:6"];
+  n2 [label="helper\nhelper.go:3"];
+  n1 -> n0 [label="L13"];
+  n2 -> n1 [label="L7"];
+  n1 -> n2 [label="L4"];
+  n2_more [label="More calls not shown\n(limit of 100 functions)", shape=plaintext];
+  n2_more -> n2 [style=dashed];
+}
+`, formatCallGraphDOT(testCallSections(), true))
+}
+
+func TestFormatCallGraphJSON(t *testing.T) {
+	output, err := formatCallGraphJSON(testCallSections(), false, 3)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Direction string          `json:"direction"`
+		Roots     []*callTreeJSON `json:"roots"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+
+	assert.Equal(t, "callees", parsed.Direction)
+	require.Len(t, parsed.Roots, 1)
+	root := parsed.Roots[0]
+	assert.Equal(t, "main", root.Name)
+	assert.Equal(t, "Function", root.Kind)
+	require.Len(t, root.Callees, 1)
+
+	// Call sites of callees are in the caller's file
+	helper := root.Callees[0].Callees[0]
+	assert.Equal(t, []callSiteJSON{{File: "/project/main.go", Line: 7, Column: 2}}, helper.CallSites)
+	assert.True(t, helper.Truncated)
+	assert.True(t, helper.Callees[0].Repeated)
+}
@@ -10,52 +10,109 @@ import (
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
 )
 
-func GetCallers(ctx context.Context, client *lsp.Client, symbolName string, maxDepth int) (string, error) {
-	return getCallHierarchy(ctx, client, symbolName, maxDepth, recurseIncomingCalls)
+// maxCallHierarchyNodes bounds the number of functions in a call hierarchy, so
+// that large depths on heavily connected code don't explode
+const maxCallHierarchyNodes = 100
+
+// GetCallers shows the functions that call symbolName, following calls up to
+// maxDepth levels. format is one of "text", "json", "mermaid" or "dot".
+func GetCallers(ctx context.Context, client *lsp.Client, symbolName string, maxDepth int, format string) (string, error) {
+	return getCallHierarchy(ctx, client, symbolName, maxDepth, format, true)
 }
 
-func GetCallees(ctx context.Context, client *lsp.Client, symbolName string, maxDepth int) (string, error) {
-	return getCallHierarchy(ctx, client, symbolName, maxDepth, recurseOutgoingCalls)
+// GetCallees shows the functions that symbolName calls, following calls up to
+// maxDepth levels. format is one of "text", "json", "mermaid" or "dot".
+func GetCallees(ctx context.Context, client *lsp.Client, symbolName string, maxDepth int, format string) (string, error) {
+	return getCallHierarchy(ctx, client, symbolName, maxDepth, format, false)
 }
 
-func getCallHierarchy(
-	ctx context.Context, client *lsp.Client, symbolName string, maxDepth int,
-	recurse func(ctx context.Context, client *lsp.Client, item protocol.CallHierarchyItem, result *strings.Builder, depth int, maxDepth int),
-) (string, error) {
-	// First get the symbol location like ReadDefinition does
-	symbolName, results, err := QuerySymbol(ctx, client, symbolName)
+// callTreeNode is a function in a call hierarchy. Its children are its callers
+// or its callees, depending on the direction of the hierarchy.
+type callTreeNode struct {
+	Item protocol.CallHierarchyItem
+	// Where the call between this node and its parent is made, in the caller's file
+	CallSites []protocol.Range
+	Children  []*callTreeNode
+	Repeated  bool // Already in the hierarchy elsewhere, so not expanded again
+	Truncated bool // Some of its calls were left out because the node budget ran out
+	Err       error
+}
+
+// callHierarchySection is the hierarchy of one symbol matching the query
+type callHierarchySection struct {
+	Name  string
+	Err   error
+	Roots []*callTreeNode
+}
+
+func getCallHierarchy(ctx context.Context, client *lsp.Client, symbolName string, maxDepth int, format string, incoming bool) (string, error) {
+	if format == "" {
+		format = "text"
+	}
+	if format != "text" && format != "json" && format != "mermaid" && format != "dot" {
+		return "", fmt.Errorf("invalid format %q, must be one of text, json, mermaid or dot", format)
+	}
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
+
+	symbols, err := prepareCallHierarchySymbols(ctx, client, symbolName)
 	if err != nil {
 		return "", err
 	}
 
-	// After this point we just return errors instead of erroring out
-	var result strings.Builder
+	builder := &callTreeBuilder{
+		ctx:      ctx,
+		client:   client,
+		incoming: incoming,
+		maxDepth: maxDepth,
+		visited:  make(map[string]bool),
+	}
 
-	for _, symbol := range results {
-		var separator string
-		if strings.Contains(symbolName, ".") {
-			separator = "."
-		} else if strings.Contains(symbolName, "::") {
-			separator = "::"
+	// After this point we just return errors instead of erroring out
+	sections := make([]callHierarchySection, 0, len(symbols))
+	for _, symbol := range symbols {
+		section := callHierarchySection{Name: symbol.Name, Err: symbol.Err}
+		for _, item := range symbol.Items {
+			section.Roots = append(section.Roots, builder.build(item))
 		}
+		sections = append(sections, section)
+	}
 
-		// Handle different matching strategies based on the search term
-		if separator != "" {
-			// For qualified names like "Type.Method", check for various matches
-			parts := strings.Split(symbolName, separator)
-			methodName := parts[len(parts)-1]
+	switch format {
+	case "json":
+		return formatCallGraphJSON(sections, incoming, maxDepth)
+	case "mermaid":
+		return formatCallGraphMermaid(sections, incoming), nil
+	case "dot":
+		return formatCallGraphDOT(sections, incoming), nil
+	}
+	return formatCallTreeText(sections, incoming), nil
+}
 
-			// Try matching the unqualified method name for languages that don't use qualified names in symbols
-			if symbol.GetName() != symbolName && symbol.GetName() != methodName {
-				continue
-			}
-		} else if symbol.GetName() != symbolName {
-			// For unqualified names, exact match only
+// callHierarchySymbol is a symbol matching a query with its call hierarchy items
+type callHierarchySymbol struct {
+	Name  string
+	Items []protocol.CallHierarchyItem
+	Err   error
+}
+
+// prepareCallHierarchySymbols finds the symbols matching symbolName the same
+// way as ReadDefinition and prepares call hierarchy items for them. Failing to
+// prepare one symbol is recorded instead of returned, so the others are kept.
+func prepareCallHierarchySymbols(ctx context.Context, client *lsp.Client, symbolName string) ([]callHierarchySymbol, error) {
+	// First get the symbol location like ReadDefinition does
+	symbolName, results, err := QuerySymbol(ctx, client, symbolName)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []callHierarchySymbol
+	for _, symbol := range results {
+		if !matchesSymbolName(symbol, symbolName) {
 			continue
 		}
 
-		result.WriteString("\n---\n")
-
 		// Get the location of the symbol
 		loc := symbol.GetLocation()
 
@@ -68,79 +125,143 @@ func getCallHierarchy(
 			},
 		}
 		items, err := client.PrepareCallHierarchy(ctx, chParams)
-		if err != nil {
-			result.WriteString(fmt.Sprintf("%s: Error: %v\n", symbol.GetName(), err))
-			continue
-		}
-
-		for _, item := range items {
-			recurse(ctx, client, item, &result, 0, maxDepth)
-		}
+		symbols = append(symbols, callHierarchySymbol{Name: symbol.GetName(), Items: items, Err: err})
 	}
 
-	return result.String(), nil
+	return symbols, nil
 }
 
-func recurseIncomingCalls(ctx context.Context, client *lsp.Client, item protocol.CallHierarchyItem, result *strings.Builder, depth int, maxDepth int) {
+// callHierarchyCall is a caller or callee of an item with the ranges of the
+// calls in the caller's file
+type callHierarchyCall struct {
+	Item      protocol.CallHierarchyItem
+	CallSites []protocol.Range
+}
 
-	var prefix string
-	if depth != 0 {
-		prefix = strings.Repeat(" ", (depth-1)*2+2)
+// callTreeBuilder expands call hierarchy items breadth first, so that the
+// node budget is spent on the closest calls. Every function is expanded once,
+// which also protects against recursion.
+type callTreeBuilder struct {
+	ctx      context.Context
+	client   *lsp.Client
+	incoming bool
+	maxDepth int
+	visited  map[string]bool
+	nodes    int
+}
 
-		result.WriteString(strings.Repeat(" ", (depth-1)*2))
-		result.WriteRune('-')
-		result.WriteString(" Called By: ")
-	} else {
-		result.WriteString("Name: ")
+func (b *callTreeBuilder) build(item protocol.CallHierarchyItem) *callTreeNode {
+	root := &callTreeNode{Item: item}
+	if b.visited[callHierarchyItemKey(item)] {
+		root.Repeated = true
+		return root
 	}
+	b.visited[callHierarchyItemKey(item)] = true
+	b.nodes++
 
-	result.WriteString(item.Name)
-	result.WriteRune('\n')
+	type queued struct {
+		node  *callTreeNode
+		depth int
+	}
+	queue := []queued{{root, 0}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.depth >= b.maxDepth {
+			continue
+		}
 
-	result.WriteString(prefix)
-	result.WriteString("Detail: ")
-	result.WriteString(item.Detail)
-	result.WriteRune('\n')
+		calls, err := callHierarchyCalls(b.ctx, b.client, current.node.Item, b.incoming)
+		if err != nil {
+			current.node.Err = err
+			continue
+		}
 
-	result.WriteString(prefix)
-	result.WriteString("File: ")
-	result.WriteString(strings.TrimPrefix(string(item.URI), "file://"))
-	result.WriteRune('\n')
+		for _, call := range calls {
+			child := &callTreeNode{Item: call.Item, CallSites: call.CallSites}
 
-	result.WriteString(prefix)
-	fmt.Fprintf(result, "Range: L%d:C%d - L%d:C%d\n",
-		item.Range.Start.Line+1,
-		item.Range.Start.Character+1,
-		item.Range.End.Line+1,
-		item.Range.End.Character+1)
+			key := callHierarchyItemKey(call.Item)
+			if b.visited[key] {
+				child.Repeated = true
+				current.node.Children = append(current.node.Children, child)
+				continue
+			}
+			if b.nodes >= maxCallHierarchyNodes {
+				current.node.Truncated = true
+				break
+			}
+			b.visited[key] = true
+			b.nodes++
 
-	if depth >= maxDepth {
-		return
+			current.node.Children = append(current.node.Children, child)
+			queue = append(queue, queued{child, current.depth + 1})
+		}
 	}
 
-	calls, err := client.IncomingCalls(ctx, protocol.CallHierarchyIncomingCallsParams{
-		Item: item,
-	})
+	return root
+}
 
-	if err != nil {
-		result.WriteString(prefix)
-		result.WriteString("Error: ")
-		result.WriteString(err.Error())
-		result.WriteRune('\n')
-		return
+// callHierarchyCalls returns the callers of an item if incoming is set and its
+// callees otherwise, sorted by name
+func callHierarchyCalls(ctx context.Context, client *lsp.Client, item protocol.CallHierarchyItem, incoming bool) ([]callHierarchyCall, error) {
+	var calls []callHierarchyCall
+	if incoming {
+		incomingCalls, err := client.IncomingCalls(ctx, protocol.CallHierarchyIncomingCallsParams{Item: item})
+		if err != nil {
+			return nil, err
+		}
+		for _, call := range incomingCalls {
+			calls = append(calls, callHierarchyCall{Item: call.From, CallSites: call.FromRanges})
+		}
+	} else {
+		outgoingCalls, err := client.OutgoingCalls(ctx, protocol.CallHierarchyOutgoingCallsParams{Item: item})
+		if err != nil {
+			return nil, err
+		}
+		for _, call := range outgoingCalls {
+			calls = append(calls, callHierarchyCall{Item: call.To, CallSites: call.FromRanges})
+		}
 	}
 
 	// ensure output is deterministic for tests
-	sort.Slice(calls, func(i, j int) bool {
-		return calls[i].From.Name < calls[j].From.Name
+	sort.SliceStable(calls, func(i, j int) bool {
+		if calls[i].Item.Name != calls[j].Item.Name {
+			return calls[i].Item.Name < calls[j].Item.Name
+		}
+		return callHierarchyItemKey(calls[i].Item) < callHierarchyItemKey(calls[j].Item)
 	})
 
-	for _, call := range calls {
-		recurseIncomingCalls(ctx, client, call.From, result, depth+1, maxDepth)
+	return calls, nil
+}
+
+// callHierarchyItemKey identifies an item by where it is declared
+func callHierarchyItemKey(item protocol.CallHierarchyItem) string {
+	return fmt.Sprintf("%s:%d:%d", item.URI, item.SelectionRange.Start.Line, item.SelectionRange.Start.Character)
+}
+
+// formatCallTreeText formats call hierarchies as indented trees
+func formatCallTreeText(sections []callHierarchySection, incoming bool) string {
+	label := "Calls"
+	if incoming {
+		label = "Called By"
 	}
+
+	var result strings.Builder
+	for _, section := range sections {
+		result.WriteString("\n---\n")
+		if section.Err != nil {
+			result.WriteString(fmt.Sprintf("%s: Error: %v\n", section.Name, section.Err))
+			continue
+		}
+		for _, root := range section.Roots {
+			writeCallTreeNode(&result, root, 0, label)
+		}
+	}
+	return result.String()
 }
 
-func recurseOutgoingCalls(ctx context.Context, client *lsp.Client, item protocol.CallHierarchyItem, result *strings.Builder, depth int, maxDepth int) {
+func writeCallTreeNode(result *strings.Builder, node *callTreeNode, depth int, label string) {
+	item := node.Item
 
 	var prefix string
 	if depth != 0 {
@@ -148,12 +269,16 @@ func recurseOutgoingCalls(ctx context.Context, client *lsp.Client, item protocol
 
 		result.WriteString(strings.Repeat(" ", (depth-1)*2))
 		result.WriteRune('-')
-		result.WriteString(" Calls: ")
+		result.WriteString(" " + label + ": ")
 	} else {
 		result.WriteString("Name: ")
 	}
 
 	result.WriteString(item.Name)
+	if node.Repeated {
+		result.WriteString(" (shown elsewhere)\n")
+		return
+	}
 	result.WriteRune('\n')
 
 	result.WriteString(prefix)
@@ -173,28 +298,20 @@ func recurseOutgoingCalls(ctx context.Context, client *lsp.Client, item protocol
 		item.Range.End.Line+1,
 		item.Range.End.Character+1)
 
-	if depth >= maxDepth {
-		return
-	}
-
-	calls, err := client.OutgoingCalls(ctx, protocol.CallHierarchyOutgoingCallsParams{
-		Item: item,
-	})
-
-	if err != nil {
+	if node.Err != nil {
 		result.WriteString(prefix)
 		result.WriteString("Error: ")
-		result.WriteString(err.Error())
+		result.WriteString(node.Err.Error())
 		result.WriteRune('\n')
 		return
 	}
 
-	// ensure output is deterministic for tests
-	sort.Slice(calls, func(i, j int) bool {
-		return calls[i].To.Name < calls[j].To.Name
-	})
+	for _, child := range node.Children {
+		writeCallTreeNode(result, child, depth+1, label)
+	}
 
-	for _, call := range calls {
-		recurseOutgoingCalls(ctx, client, call.To, result, depth+1, maxDepth)
+	if node.Truncated {
+		result.WriteString(strings.Repeat(" ", depth*2))
+		fmt.Fprintf(result, "- More calls not shown, the limit of %d functions was reached\n", maxCallHierarchyNodes)
 	}
 }
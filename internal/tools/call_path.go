@@ -0,0 +1,310 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// maxCallPaths bounds the number of shortest call paths that are listed
+const maxCallPaths = 5
+
+// callPathEdge is a call from one function to another, identified by their
+// call hierarchy item keys
+type callPathEdge struct {
+	Caller    string
+	Callee    string
+	CallSites []protocol.Range // In the caller's file
+}
+
+// callPathSide is one direction of the bidirectional search: forward from the
+// source through callees, or backward from the target through callers
+type callPathSide struct {
+	incoming bool
+	depth    int
+	frontier []string
+	dist     map[string]int
+	// The edges through which a function was first reached, at its distance
+	parents map[string][]callPathEdge
+}
+
+// callPathSearch finds the shortest call chains between two sets of functions
+type callPathSearch struct {
+	ctx      context.Context
+	client   *lsp.Client
+	items    map[string]protocol.CallHierarchyItem
+	forward  *callPathSide
+	backward *callPathSide
+	// The node budget ran out, so shorter paths than the ones found may exist
+	truncated bool
+}
+
+// FindCallPath finds the shortest chains of calls from the function source to
+// the function target, looking at most maxDepth calls deep. It searches from
+// both ends at once, following callees of source and callers of target.
+func FindCallPath(ctx context.Context, client *lsp.Client, source, target string, maxDepth int) (string, error) {
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
+
+	sourceItems, err := callPathEndpoint(ctx, client, source)
+	if err != nil {
+		return "", err
+	}
+	targetItems, err := callPathEndpoint(ctx, client, target)
+	if err != nil {
+		return "", err
+	}
+
+	search := &callPathSearch{
+		ctx:      ctx,
+		client:   client,
+		items:    make(map[string]protocol.CallHierarchyItem),
+		forward:  newCallPathSide(false),
+		backward: newCallPathSide(true),
+	}
+	for _, item := range sourceItems {
+		search.add(search.forward, item)
+	}
+	for _, item := range targetItems {
+		search.add(search.backward, item)
+	}
+
+	meeting := search.meetingPoints()
+	for len(meeting) == 0 && search.forward.depth+search.backward.depth < maxDepth {
+		// Expand the side with the smaller frontier, which keeps the search narrow
+		side := search.forward
+		if len(search.backward.frontier) < len(search.forward.frontier) {
+			side = search.backward
+		}
+		if len(side.frontier) == 0 {
+			break
+		}
+		search.expand(side)
+		meeting = search.meetingPoints()
+	}
+
+	if len(meeting) == 0 {
+		result := fmt.Sprintf("No call path found from %s to %s within %d calls", source, target, maxDepth)
+		if search.truncated {
+			result += fmt.Sprintf(". The search stopped after visiting %d functions, so a path may still exist", maxCallHierarchyNodes)
+		}
+		return result, nil
+	}
+
+	paths := search.paths(meeting)
+	length := len(paths[0])
+	if length == 0 {
+		return fmt.Sprintf("%s and %s are the same function: %s", source, target, formatCallPathItem(search.items[meeting[0]])), nil
+	}
+
+	var output strings.Builder
+	if len(paths) == 1 {
+		fmt.Fprintf(&output, "Found the shortest call path from %s to %s (%d calls):\n", source, target, length)
+	} else {
+		fmt.Fprintf(&output, "Found %d shortest call paths from %s to %s (%d calls each):\n", len(paths), source, target, length)
+	}
+	if len(paths) == maxCallPaths {
+		fmt.Fprintf(&output, "Showing the first %d paths, there may be more\n", maxCallPaths)
+	}
+	if search.truncated {
+		fmt.Fprintf(&output, "Note: the search stopped expanding after visiting %d functions\n", maxCallHierarchyNodes)
+	}
+
+	for i, path := range paths {
+		fmt.Fprintf(&output, "\nPath %d:\n", i+1)
+		output.WriteString(formatCallPathItem(search.items[path[0].Caller]))
+		for _, edge := range path {
+			for _, callSite := range edge.CallSites {
+				fmt.Fprintf(&output, "  -> called at %s L%d:C%d\n",
+					strings.TrimPrefix(string(search.items[edge.Caller].URI), "file://"),
+					callSite.Start.Line+1, callSite.Start.Character+1)
+			}
+			output.WriteString(formatCallPathItem(search.items[edge.Callee]))
+		}
+	}
+
+	return output.String(), nil
+}
+
+// callPathEndpoint prepares the call hierarchy items for one end of a path
+func callPathEndpoint(ctx context.Context, client *lsp.Client, symbolName string) ([]protocol.CallHierarchyItem, error) {
+	symbols, err := prepareCallHierarchySymbols(ctx, client, symbolName)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []protocol.CallHierarchyItem
+	for _, symbol := range symbols {
+		if symbol.Err != nil {
+			toolsLogger.Error("Failed to prepare call hierarchy for %s: %v", symbol.Name, symbol.Err)
+			continue
+		}
+		items = append(items, symbol.Items...)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no function found matching '%s'", symbolName)
+	}
+	return items, nil
+}
+
+func newCallPathSide(incoming bool) *callPathSide {
+	return &callPathSide{
+		incoming: incoming,
+		dist:     make(map[string]int),
+		parents:  make(map[string][]callPathEdge),
+	}
+}
+
+// add adds a starting point of a side
+func (s *callPathSearch) add(side *callPathSide, item protocol.CallHierarchyItem) {
+	key := callHierarchyItemKey(item)
+	if _, ok := side.dist[key]; ok {
+		return
+	}
+	s.items[key] = item
+	side.dist[key] = 0
+	side.frontier = append(side.frontier, key)
+}
+
+// expand follows the calls of every function in the frontier of a side, one
+// level deeper
+func (s *callPathSearch) expand(side *callPathSide) {
+	var next []string
+	for _, key := range side.frontier {
+		calls, err := callHierarchyCalls(s.ctx, s.client, s.items[key], side.incoming)
+		if err != nil {
+			toolsLogger.Error("Failed to get calls of %s: %v", s.items[key].Name, err)
+			continue
+		}
+
+		for _, call := range calls {
+			callKey := callHierarchyItemKey(call.Item)
+
+			// Edges point from caller to callee on both sides
+			edge := callPathEdge{Caller: key, Callee: callKey, CallSites: call.CallSites}
+			if side.incoming {
+				edge = callPathEdge{Caller: callKey, Callee: key, CallSites: call.CallSites}
+			}
+
+			if dist, ok := side.dist[callKey]; ok {
+				// Another shortest way to reach it
+				if dist == side.depth+1 {
+					side.parents[callKey] = append(side.parents[callKey], edge)
+				}
+				continue
+			}
+
+			if _, ok := s.items[callKey]; !ok {
+				if len(s.items) >= maxCallHierarchyNodes {
+					s.truncated = true
+					continue
+				}
+				s.items[callKey] = call.Item
+			}
+			side.dist[callKey] = side.depth + 1
+			side.parents[callKey] = []callPathEdge{edge}
+			next = append(next, callKey)
+		}
+	}
+
+	side.frontier = next
+	side.depth++
+}
+
+// meetingPoints returns the functions reached from both sides that lie on a
+// shortest path, sorted for deterministic output
+func (s *callPathSearch) meetingPoints() []string {
+	best := -1
+	var meeting []string
+	for key, forwardDist := range s.forward.dist {
+		backwardDist, ok := s.backward.dist[key]
+		if !ok {
+			continue
+		}
+		switch length := forwardDist + backwardDist; {
+		case best == -1 || length < best:
+			best = length
+			meeting = []string{key}
+		case length == best:
+			meeting = append(meeting, key)
+		}
+	}
+	sort.Strings(meeting)
+	return meeting
+}
+
+// paths lists up to maxCallPaths distinct shortest paths through the meeting
+// points, each as the edges from the source to the target
+func (s *callPathSearch) paths(meeting []string) [][]callPathEdge {
+	var toSource func(key string) [][]callPathEdge
+	toSource = func(key string) [][]callPathEdge {
+		if s.forward.dist[key] == 0 {
+			return [][]callPathEdge{{}}
+		}
+		var paths [][]callPathEdge
+		for _, edge := range s.forward.parents[key] {
+			for _, path := range toSource(edge.Caller) {
+				paths = append(paths, append(path[:len(path):len(path)], edge))
+				if len(paths) == maxCallPaths {
+					return paths
+				}
+			}
+		}
+		return paths
+	}
+
+	var toTarget func(key string) [][]callPathEdge
+	toTarget = func(key string) [][]callPathEdge {
+		if s.backward.dist[key] == 0 {
+			return [][]callPathEdge{{}}
+		}
+		var paths [][]callPathEdge
+		for _, edge := range s.backward.parents[key] {
+			for _, path := range toTarget(edge.Callee) {
+				paths = append(paths, append([]callPathEdge{edge}, path...))
+				if len(paths) == maxCallPaths {
+					return paths
+				}
+			}
+		}
+		return paths
+	}
+
+	seen := make(map[string]bool)
+	var paths [][]callPathEdge
+	for _, key := range meeting {
+		for _, head := range toSource(key) {
+			for _, tail := range toTarget(key) {
+				path := append(head[:len(head):len(head)], tail...)
+
+				// A path can pass through several meeting points
+				var signature strings.Builder
+				for _, edge := range path {
+					signature.WriteString(edge.Caller + ">" + edge.Callee + "|")
+				}
+				if seen[signature.String()] {
+					continue
+				}
+				seen[signature.String()] = true
+
+				paths = append(paths, path)
+				if len(paths) == maxCallPaths {
+					return paths
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// formatCallPathItem formats a function on a call path on one line
+func formatCallPathItem(item protocol.CallHierarchyItem) string {
+	return fmt.Sprintf("%s (%s) in %s L%d:C%d\n", item.Name, protocol.TableKindMap[item.Kind],
+		strings.TrimPrefix(string(item.URI), "file://"),
+		item.SelectionRange.Start.Line+1, item.SelectionRange.Start.Character+1)
+}
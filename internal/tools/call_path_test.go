@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallPathSearchPaths(t *testing.T) {
+	// Handler calls Service and Cache, which both call Exec. Logger is only
+	// reached from the target side and is not on a shortest path.
+	search := &callPathSearch{
+		items:    make(map[string]protocol.CallHierarchyItem),
+		forward:  newCallPathSide(false),
+		backward: newCallPathSide(true),
+	}
+	edge := func(caller, callee string, line uint32) callPathEdge {
+		return callPathEdge{Caller: caller, Callee: callee, CallSites: callSite(line)}
+	}
+
+	search.forward.dist = map[string]int{"Handler": 0, "Service": 1, "Cache": 1}
+	search.forward.parents = map[string][]callPathEdge{
+		"Service": {edge("Handler", "Service", 10)},
+		"Cache":   {edge("Handler", "Cache", 11)},
+	}
+	search.backward.dist = map[string]int{"Exec": 0, "Service": 1, "Cache": 1, "Logger": 1}
+	search.backward.parents = map[string][]callPathEdge{
+		"Service": {edge("Service", "Exec", 20)},
+		"Cache":   {edge("Cache", "Exec", 30)},
+		"Logger":  {edge("Logger", "Exec", 40)},
+	}
+
+	meeting := search.meetingPoints()
+	assert.Equal(t, []string{"Cache", "Service"}, meeting)
+
+	assert.Equal(t, [][]callPathEdge{
+		{edge("Handler", "Cache", 11), edge("Cache", "Exec", 30)},
+		{edge("Handler", "Service", 10), edge("Service", "Exec", 20)},
+	}, search.paths(meeting))
+}
+
+func TestCallPathSearchPathsThroughSeveralMeetingPoints(t *testing.T) {
+	// Both sides reached both A and B on the path Source -> A -> B -> Target,
+	// which must only be listed once
+	search := &callPathSearch{
+		items:    make(map[string]protocol.CallHierarchyItem),
+		forward:  newCallPathSide(false),
+		backward: newCallPathSide(true),
+	}
+	ab := callPathEdge{Caller: "A", Callee: "B"}
+	search.forward.dist = map[string]int{"Source": 0, "A": 1, "B": 2}
+	search.forward.parents = map[string][]callPathEdge{
+		"A": {{Caller: "Source", Callee: "A"}},
+		"B": {ab},
+	}
+	search.backward.dist = map[string]int{"Target": 0, "B": 1, "A": 2}
+	search.backward.parents = map[string][]callPathEdge{
+		"B": {{Caller: "B", Callee: "Target"}},
+		"A": {ab},
+	}
+
+	meeting := search.meetingPoints()
+	assert.Equal(t, []string{"A", "B"}, meeting)
+	assert.Len(t, search.paths(meeting), 1)
+}
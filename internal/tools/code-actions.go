@@ -0,0 +1,287 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+)
+
+// CodeActionOptions selects the part of a file to request code actions for.
+// Either Diagnostic or StartLine must be set.
+type CodeActionOptions struct {
+	StartLine   int      // 1-indexed start line of the range
+	StartColumn int      // 1-indexed start column, defaults to the start of the line
+	EndLine     int      // 1-indexed end line, defaults to StartLine
+	EndColumn   int      // 1-indexed end column, defaults to the end of the line
+	Diagnostic  string   // Location of a diagnostic as reported by the diagnostics tool, e.g. "L12:C5"
+	Kinds       []string // Only request actions of these kinds, e.g. "quickfix", "refactor.extract"
+}
+
+// GetCodeActions lists the code actions (quick fixes, refactorings, source actions)
+// that the language server offers for a range or a diagnostic in a file
+func GetCodeActions(ctx context.Context, client *lsp.Client, filePath string, opts CodeActionOptions) (string, error) {
+	actions, rng, err := requestCodeActions(ctx, client, filePath, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if len(actions) == 0 {
+		return fmt.Sprintf("No code actions available for %s L%d:C%d - L%d:C%d",
+			filePath, rng.Start.Line+1, rng.Start.Character+1, rng.End.Line+1, rng.End.Character+1), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Code actions for %s L%d:C%d - L%d:C%d:\n\n",
+		filePath, rng.Start.Line+1, rng.Start.Character+1, rng.End.Line+1, rng.End.Character+1))
+
+	for i, item := range actions {
+		switch action := item.Value.(type) {
+		case protocol.CodeAction:
+			output.WriteString(fmt.Sprintf("[%d] %s\n", i+1, action.Title))
+			if action.Kind != "" {
+				kind := string(action.Kind)
+				if action.IsPreferred {
+					kind += " (preferred)"
+				}
+				output.WriteString(fmt.Sprintf("    Kind: %s\n", kind))
+			}
+			for _, diag := range action.Diagnostics {
+				output.WriteString(fmt.Sprintf("    Fixes: %s at L%d:C%d: %s\n",
+					getSeverityString(diag.Severity), diag.Range.Start.Line+1, diag.Range.Start.Character+1, diag.Message))
+			}
+			if action.Disabled != nil {
+				output.WriteString(fmt.Sprintf("    Disabled: %s\n", action.Disabled.Reason))
+			}
+			if action.Edit != nil {
+				output.WriteString("    Edits:\n")
+				for _, line := range strings.Split(strings.TrimSuffix(describeWorkspaceEdit(*action.Edit), "\n"), "\n") {
+					output.WriteString("      " + line + "\n")
+				}
+			}
+			if action.Command != nil {
+				output.WriteString(fmt.Sprintf("    Command: %s\n", action.Command.Command))
+			}
+		case protocol.Command:
+			output.WriteString(fmt.Sprintf("[%d] %s\n", i+1, action.Title))
+			output.WriteString(fmt.Sprintf("    Command: %s\n", action.Command))
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString(fmt.Sprintf("Found %d code actions.\n", len(actions)))
+
+	return output.String(), nil
+}
+
+// ApplyCodeAction applies the code action with the given 1-indexed position in
+// the list returned by GetCodeActions for the same file and options. The
+// actions are requested again, so the action's title has to be passed too, to
+// make sure the list hasn't changed in between.
+func ApplyCodeAction(ctx context.Context, client *lsp.Client, filePath string, opts CodeActionOptions, index int, title string) (string, error) {
+	if strings.TrimSpace(title) == "" {
+		return "", fmt.Errorf("title is required")
+	}
+
+	actions, _, err := requestCodeActions(ctx, client, filePath, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if len(actions) == 0 {
+		return "", fmt.Errorf("no code actions available")
+	}
+
+	if index < 1 || index > len(actions) {
+		return "", fmt.Errorf("invalid code action index: %d. Available range: 1-%d", index, len(actions))
+	}
+
+	action := actions[index-1]
+	if actual := codeActionTitle(action); actual != strings.TrimSpace(title) {
+		return "", fmt.Errorf("code action %d is now %q, not %q. List the code actions again", index, actual, title)
+	}
+
+	return applyCodeAction(ctx, client, action)
+}
+
+// codeActionTitle returns the title of a code action or command
+func codeActionTitle(item protocol.Or_Result_textDocument_codeAction_Item0_Elem) string {
+	switch v := item.Value.(type) {
+	case protocol.CodeAction:
+		return v.Title
+	case protocol.Command:
+		return v.Title
+	}
+	return ""
+}
+
+// applyCodeAction resolves a code action if needed, applies its workspace edit
+// and then runs its command, in the order required by the specification
+func applyCodeAction(ctx context.Context, client *lsp.Client, item protocol.Or_Result_textDocument_codeAction_Item0_Elem) (string, error) {
+	var action protocol.CodeAction
+	switch v := item.Value.(type) {
+	case protocol.CodeAction:
+		action = v
+	case protocol.Command:
+		action = protocol.CodeAction{Title: v.Title, Command: &v}
+	default:
+		return "", fmt.Errorf("unknown code action type: %T", item.Value)
+	}
+
+	if action.Disabled != nil {
+		return "", fmt.Errorf("code action %q is disabled: %s", action.Title, action.Disabled.Reason)
+	}
+
+	// Servers may leave the edit out and fill it in on codeAction/resolve
+	if action.Edit == nil && supportsCodeActionResolve(client.ServerCapabilities()) {
+		resolved, err := client.ResolveCodeAction(ctx, action)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve code action: %v", err)
+		}
+		action = resolved
+	}
+
+	if action.Edit == nil && action.Command == nil {
+		return "", fmt.Errorf("code action %q has no edit or command", action.Title)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Applied code action: %s\n", action.Title))
+
+	if action.Edit != nil {
+		if err := utilities.ApplyWorkspaceEdit(*action.Edit); err != nil {
+			return "", fmt.Errorf("failed to apply changes: %v", err)
+		}
+		output.WriteString(describeWorkspaceEdit(*action.Edit))
+	}
+
+	// Commands usually apply their changes with workspace/applyEdit
+	if action.Command != nil {
+		result, err := runCommand(ctx, client, action.Command.Command, action.Command.Arguments)
+		if err != nil {
+			return "", fmt.Errorf("failed to execute code action command: %v", err)
+		}
+		output.WriteString(fmt.Sprintf("Executed command: %s\n\n%s", action.Command.Command, formatCommandResult(result)))
+	}
+
+	return output.String(), nil
+}
+
+// supportsCodeActionResolve reports whether the server fills in code actions
+// with codeAction/resolve. The provider is either a bool or CodeActionOptions.
+func supportsCodeActionResolve(capabilities protocol.ServerCapabilities) bool {
+	switch provider := capabilities.CodeActionProvider.(type) {
+	case protocol.CodeActionOptions:
+		return provider.ResolveProvider
+	case map[string]any:
+		resolve, _ := provider["resolveProvider"].(bool)
+		return resolve
+	}
+	return false
+}
+
+// requestCodeActions sends a textDocument/codeAction request for the range
+// described by opts, passing along the cached diagnostics that overlap it
+func requestCodeActions(ctx context.Context, client *lsp.Client, filePath string, opts CodeActionOptions) ([]protocol.Or_Result_textDocument_codeAction_Item0_Elem, protocol.Range, error) {
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return nil, protocol.Range{}, fmt.Errorf("could not open file: %v", err)
+	}
+
+	uri := protocol.DocumentUri("file://" + filePath)
+	diagnostics := client.GetFileDiagnostics(uri)
+
+	var rng protocol.Range
+	var contextDiagnostics []protocol.Diagnostic
+
+	if opts.Diagnostic != "" {
+		for _, diag := range diagnostics {
+			location := fmt.Sprintf("L%d:C%d", diag.Range.Start.Line+1, diag.Range.Start.Character+1)
+			if location == opts.Diagnostic {
+				rng = diag.Range
+				contextDiagnostics = append(contextDiagnostics, diag)
+			}
+		}
+		if len(contextDiagnostics) == 0 {
+			return nil, protocol.Range{}, fmt.Errorf("no diagnostic found at %s in %s, run the diagnostics tool first", opts.Diagnostic, filePath)
+		}
+	} else {
+		rng, err = codeActionRange(filePath, opts)
+		if err != nil {
+			return nil, protocol.Range{}, err
+		}
+		for _, diag := range diagnostics {
+			if utilities.RangesOverlap(diag.Range, rng) {
+				contextDiagnostics = append(contextDiagnostics, diag)
+			}
+		}
+	}
+
+	kinds := make([]protocol.CodeActionKind, len(opts.Kinds))
+	for i, kind := range opts.Kinds {
+		kinds[i] = protocol.CodeActionKind(kind)
+	}
+
+	triggerKind := protocol.CodeActionInvoked
+	actions, err := client.CodeAction(ctx, protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: uri,
+		},
+		Range: rng,
+		Context: protocol.CodeActionContext{
+			Diagnostics: contextDiagnostics,
+			Only:        kinds,
+			TriggerKind: &triggerKind,
+		},
+	})
+	if err != nil {
+		return nil, protocol.Range{}, fmt.Errorf("failed to get code actions: %v", err)
+	}
+
+	return actions, rng, nil
+}
+
+// codeActionRange converts the 1-indexed range in opts to a protocol.Range,
+// defaulting to whole lines when columns are left out
+func codeActionRange(filePath string, opts CodeActionOptions) (protocol.Range, error) {
+	if opts.StartLine < 1 {
+		return protocol.Range{}, fmt.Errorf("either diagnostic or startLine is required")
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return protocol.Range{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+
+	endLine := opts.EndLine
+	if endLine < opts.StartLine {
+		endLine = opts.StartLine
+	}
+	if endLine > len(lines) {
+		return protocol.Range{}, fmt.Errorf("end line %d is beyond end of file (%d lines)", endLine, len(lines))
+	}
+
+	startColumn := max(opts.StartColumn, 1)
+	endColumn := opts.EndColumn
+	if endColumn < 1 {
+		// Columns are counted in UTF-16 code units like protocol positions
+		endColumn = len(utf16.Encode([]rune(strings.TrimSuffix(lines[endLine-1], "\r")))) + 1
+	}
+
+	return protocol.Range{
+		Start: protocol.Position{
+			Line:      uint32(opts.StartLine - 1),
+			Character: uint32(startColumn - 1),
+		},
+		End: protocol.Position{
+			Line:      uint32(endLine - 1),
+			Character: uint32(endColumn - 1),
+		},
+	}, nil
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeActionRange(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "main.go")
+	err := os.WriteFile(filePath, []byte("package main\r\n\nvar s = \"héllo 😀\"\n"), 0644)
+	assert.NoError(t, err)
+
+	// Whole lines end at the line's length in UTF-16 code units
+	rng, err := codeActionRange(filePath, CodeActionOptions{StartLine: 1, EndLine: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, protocol.Range{
+		Start: protocol.Position{Line: 0, Character: 0},
+		End:   protocol.Position{Line: 2, Character: 18},
+	}, rng)
+
+	rng, err = codeActionRange(filePath, CodeActionOptions{StartLine: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(12), rng.End.Character)
+
+	_, err = codeActionRange(filePath, CodeActionOptions{StartLine: 10})
+	assert.Error(t, err)
+
+	_, err = codeActionRange(filePath, CodeActionOptions{})
+	assert.Error(t, err)
+}
+
+func TestSupportsCodeActionResolve(t *testing.T) {
+	assert.False(t, supportsCodeActionResolve(protocol.ServerCapabilities{}))
+	assert.False(t, supportsCodeActionResolve(protocol.ServerCapabilities{CodeActionProvider: true}))
+	assert.False(t, supportsCodeActionResolve(protocol.ServerCapabilities{
+		CodeActionProvider: map[string]any{"codeActionKinds": []any{"quickfix"}},
+	}))
+	assert.True(t, supportsCodeActionResolve(protocol.ServerCapabilities{
+		CodeActionProvider: map[string]any{"resolveProvider": true},
+	}))
+	assert.True(t, supportsCodeActionResolve(protocol.ServerCapabilities{
+		CodeActionProvider: protocol.CodeActionOptions{ResolveProvider: true},
+	}))
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// commandProgressTimeout bounds how long runCommand waits for a command the
+// server runs in the background, like running tests
+const commandProgressTimeout = 2 * time.Minute
+
+// commandTokens numbers the progress tokens sent with commands
+var commandTokens atomic.Int32
+
+// commandResult is what a command returned and what it did to the workspace
+type commandResult struct {
+	Result       any
+	Edits        []lsp.AppliedEdit
+	Messages     []protocol.ShowMessageParams
+	StillRunning bool // The command didn't finish within commandProgressTimeout
+}
+
+// runCommand executes a command with workspace/executeCommand and captures the
+// edits the server applies and the messages it shows while it runs. Servers
+// may run a command in the background and report progress on it, e.g. gopls
+// does for running tests, in which case this waits for it to finish. Commands
+// run one at a time, so that their effects aren't mixed up.
+func runCommand(ctx context.Context, client *lsp.Client, command string, arguments []json.RawMessage) (commandResult, error) {
+	token := protocol.ProgressToken{Value: fmt.Sprintf("mcp-language-server-command-%d", commandTokens.Add(1))}
+	effects, err := client.CaptureServerEffects(ctx, token)
+	if err != nil {
+		return commandResult{}, err
+	}
+	defer client.StopCapture(effects)
+
+	params := protocol.ExecuteCommandParams{
+		Command:   command,
+		Arguments: arguments,
+	}
+	params.WorkDoneToken = token
+
+	result, err := client.ExecuteCommand(ctx, params)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	// Progress for the command may have been sent before the response
+	if err := client.WaitForNotifications(ctx); err != nil {
+		return commandResult{}, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, commandProgressTimeout)
+	defer cancel()
+
+	stillRunning := false
+	if err := effects.WaitForProgress(waitCtx); err != nil {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return commandResult{}, err
+		}
+		stillRunning = true
+	}
+
+	return commandResult{
+		Result:       result,
+		Edits:        effects.Edits(),
+		Messages:     effects.Messages(),
+		StillRunning: stillRunning,
+	}, nil
+}
+
+// formatCommandResult describes the result of a command, the edits it applied
+// and the messages the server sent while it ran
+func formatCommandResult(result commandResult) string {
+	var output strings.Builder
+
+	if result.Result != nil {
+		data, err := json.MarshalIndent(result.Result, "", "  ")
+		if err == nil {
+			output.WriteString(fmt.Sprintf("Result:\n%s\n\n", data))
+		}
+	}
+
+	if len(result.Edits) > 0 {
+		output.WriteString("Edits:\n")
+		for _, edit := range result.Edits {
+			label := edit.Label
+			if label == "" {
+				label = "Workspace edit"
+			}
+			if edit.Applied {
+				output.WriteString(fmt.Sprintf("%s (applied):\n", label))
+			} else {
+				output.WriteString(fmt.Sprintf("%s (failed: %s):\n", label, edit.FailureReason))
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(describeWorkspaceEdit(edit.Edit), "\n"), "\n") {
+				output.WriteString("  " + line + "\n")
+			}
+		}
+		output.WriteString("\n")
+	}
+
+	if len(result.Messages) > 0 {
+		output.WriteString("Messages from the server:\n")
+		for _, msg := range result.Messages {
+			output.WriteString(fmt.Sprintf("  %s: %s\n", messageTypeString(msg.Type), msg.Message))
+		}
+		output.WriteString("\n")
+	}
+
+	if result.StillRunning {
+		output.WriteString(fmt.Sprintf("The command was still running after %s. Its remaining effects are not shown.\n", commandProgressTimeout))
+	} else if len(result.Edits) == 0 && len(result.Messages) == 0 {
+		output.WriteString("The command made no edits and the server sent no messages.\n")
+	}
+
+	return strings.TrimRight(output.String(), "\n") + "\n"
+}
+
+func messageTypeString(messageType protocol.MessageType) string {
+	switch messageType {
+	case protocol.Error:
+		return "Error"
+	case protocol.Warning:
+		return "Warning"
+	case protocol.Info:
+		return "Info"
+	case protocol.Log:
+		return "Log"
+	case protocol.Debug:
+		return "Debug"
+	default:
+		return fmt.Sprintf("Message(%d)", messageType)
+	}
+}
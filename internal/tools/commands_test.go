@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatCommandResult(t *testing.T) {
+	result := commandResult{
+		Result: map[string]any{"tests": 2},
+		Edits: []lsp.AppliedEdit{
+			{
+				Label: "Tidy",
+				Edit: protocol.WorkspaceEdit{
+					Changes: map[protocol.DocumentUri][]protocol.TextEdit{
+						"file:///project/go.mod": {{NewText: ""}},
+					},
+				},
+				Applied: true,
+			},
+			{
+				Edit:          protocol.WorkspaceEdit{},
+				FailureReason: "file changed",
+			},
+		},
+		Messages: []protocol.ShowMessageParams{
+			{Type: protocol.Info, Message: "all tests passed"},
+		},
+	}
+
+	assert.Equal(t, `Result:
+{
+  "tests": 2
+}
+
+Edits:
+Tidy (applied):
+  /project/go.mod: 1 edits
+Workspace edit (failed: file changed):
+  No changes
+
+Messages from the server:
+  Info: all tests passed
+`, formatCommandResult(result))
+}
+
+func TestFormatCommandResultNoEffects(t *testing.T) {
+	assert.Equal(t, "The command made no edits and the server sent no messages.\n",
+		formatCommandResult(commandResult{}))
+
+	assert.Contains(t, formatCommandResult(commandResult{StillRunning: true}), "still running")
+}
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// maxCompletionDocLength is the length at which completion documentation is cut off
+const maxCompletionDocLength = 200
+
+// GetCompletions returns the top completion candidates at a position, optionally
+// filtered to those starting with prefix. Items are resolved for their detail and
+// documentation when the server supports it.
+func GetCompletions(ctx context.Context, client *lsp.Client, filePath string, line, column int, prefix string, limit int) (string, error) {
+	// Open the file if not already open
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	if limit < 1 {
+		limit = 20
+	}
+
+	// Convert 1-indexed line/column to 0-indexed for LSP protocol
+	params := protocol.CompletionParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: protocol.DocumentUri("file://" + filePath),
+			},
+			Position: protocol.Position{
+				Line:      uint32(line - 1),
+				Character: uint32(column - 1),
+			},
+		},
+		Context: protocol.CompletionContext{
+			TriggerKind: protocol.Invoked,
+		},
+	}
+
+	result, err := client.Completion(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to get completions: %v", err)
+	}
+
+	items, err := result.Items()
+	if err != nil {
+		return "", fmt.Errorf("failed to process completions: %v", err)
+	}
+
+	items = filterCompletionItems(items, prefix)
+	if len(items) == 0 {
+		if prefix != "" {
+			return fmt.Sprintf("No completions starting with %q at %s L%d:C%d", prefix, filePath, line, column), nil
+		}
+		return fmt.Sprintf("No completions available at %s L%d:C%d", filePath, line, column), nil
+	}
+
+	total := len(items)
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	// Only the items that are shown are resolved, since each one is a request
+	capabilities := client.ServerCapabilities()
+	if capabilities.CompletionProvider != nil && capabilities.CompletionProvider.ResolveProvider {
+		for i, item := range items {
+			if item.Detail != "" && item.Documentation != nil {
+				continue
+			}
+			resolved, err := client.ResolveCompletionItem(ctx, item)
+			if err != nil {
+				toolsLogger.Debug("Failed to resolve completion item %s: %v", item.Label, err)
+				continue
+			}
+			items[i] = resolved
+		}
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Completions at %s L%d:C%d", filePath, line, column))
+	if prefix != "" {
+		output.WriteString(fmt.Sprintf(" starting with %q", prefix))
+	}
+	output.WriteString(fmt.Sprintf(" (showing %d of %d", len(items), total))
+	if list, ok := result.Value.(protocol.CompletionList); ok && list.IsIncomplete {
+		output.WriteString(", list is incomplete, type more of the name to narrow it down")
+	}
+	output.WriteString("):\n\n")
+
+	for i, item := range items {
+		output.WriteString(formatCompletionItem(i+1, item))
+	}
+
+	return output.String(), nil
+}
+
+// filterCompletionItems keeps the items whose filter text starts with prefix,
+// ignoring case, and sorts them the way the server ranked them. Items matching
+// the case of prefix come first.
+func filterCompletionItems(items []protocol.CompletionItem, prefix string) []protocol.CompletionItem {
+	filterText := func(item protocol.CompletionItem) string {
+		if item.FilterText != "" {
+			return item.FilterText
+		}
+		return item.Label
+	}
+
+	var filtered []protocol.CompletionItem
+	for _, item := range items {
+		if strings.HasPrefix(strings.ToLower(filterText(item)), strings.ToLower(prefix)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	sortText := func(item protocol.CompletionItem) string {
+		if item.SortText != "" {
+			return item.SortText
+		}
+		return item.Label
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		iExact := strings.HasPrefix(filterText(filtered[i]), prefix)
+		jExact := strings.HasPrefix(filterText(filtered[j]), prefix)
+		if iExact != jExact {
+			return iExact
+		}
+		if filtered[i].Preselect != filtered[j].Preselect {
+			return filtered[i].Preselect
+		}
+		return sortText(filtered[i]) < sortText(filtered[j])
+	})
+
+	return filtered
+}
+
+func formatCompletionItem(index int, item protocol.CompletionItem) string {
+	var result strings.Builder
+
+	result.WriteString(fmt.Sprintf("%d. %s", index, item.Label))
+	if item.LabelDetails != nil && item.LabelDetails.Detail != "" {
+		result.WriteString(item.LabelDetails.Detail)
+	}
+	if kind, ok := protocol.CompletionItemKindMap[item.Kind]; ok {
+		result.WriteString(fmt.Sprintf(" (%s)", kind))
+	}
+	if item.Deprecated || slices.Contains(item.Tags, protocol.ComplDeprecated) {
+		result.WriteString(" [deprecated]")
+	}
+	result.WriteString("\n")
+
+	if item.Detail != "" {
+		result.WriteString("   " + item.Detail + "\n")
+	} else if item.LabelDetails != nil && item.LabelDetails.Description != "" {
+		result.WriteString("   " + item.LabelDetails.Description + "\n")
+	}
+
+	if item.Documentation != nil {
+		if doc := shortDocumentation(markupText(item.Documentation.Value)); doc != "" {
+			result.WriteString("   " + doc + "\n")
+		}
+	}
+
+	return result.String()
+}
+
+// shortDocumentation returns the first paragraph of documentation on one line,
+// cut off at maxCompletionDocLength
+func shortDocumentation(doc string) string {
+	doc = strings.TrimSpace(doc)
+	if paragraph, _, found := strings.Cut(doc, "\n\n"); found {
+		doc = paragraph
+	}
+	doc = strings.Join(strings.Fields(doc), " ")
+
+	if runes := []rune(doc); len(runes) > maxCompletionDocLength {
+		doc = string(runes[:maxCompletionDocLength]) + "..."
+	}
+	return doc
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionResultItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name:     "CompletionList",
+			response: `{"isIncomplete": false, "items": [{"label": "Println"}, {"label": "Printf"}]}`,
+			expected: []string{"Println", "Printf"},
+		},
+		{
+			name:     "CompletionItem array",
+			response: `[{"label": "push"}, {"label": "pop"}]`,
+			expected: []string{"push", "pop"},
+		},
+		{
+			name:     "Null",
+			response: `null`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result protocol.Or_Result_textDocument_completion
+			require.NoError(t, json.Unmarshal([]byte(tt.response), &result))
+
+			items, err := result.Items()
+			require.NoError(t, err)
+
+			var labels []string
+			for _, item := range items {
+				labels = append(labels, item.Label)
+			}
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
+
+func TestFilterCompletionItems(t *testing.T) {
+	items := []protocol.CompletionItem{
+		{Label: "println", SortText: "3"},
+		{Label: "Sprintf", SortText: "1"},
+		{Label: "Println", SortText: "2"},
+		{Label: "Printf", SortText: "1"},
+		{Label: "Errorf", FilterText: "printError", SortText: "0"},
+	}
+
+	var labels []string
+	for _, item := range filterCompletionItems(items, "Print") {
+		labels = append(labels, item.Label)
+	}
+
+	// Case sensitive matches first, then by sort text
+	assert.Equal(t, []string{"Printf", "Println", "Errorf", "println"}, labels)
+}
+
+func TestShortDocumentation(t *testing.T) {
+	assert.Equal(t, "Println formats using the default formats.",
+		shortDocumentation("Println formats using\nthe default formats.\n\nSpaces are always added."))
+
+	long := ""
+	for range 30 {
+		long += "0123456789"
+	}
+	assert.Equal(t, long[:maxCompletionDocLength]+"...", shortDocumentation(long))
+}
@@ -9,28 +9,38 @@ import (
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
 )
 
-// GetContentInfo reads the source code definition of a symbol (function, type, constant, etc.) at the specified position
-func GetContentInfo(ctx context.Context, client *lsp.Client, filePath string, line, column int) (string, error) {
-	// Open the file if not already open
-	err := client.OpenFile(ctx, filePath)
+// GetContentInfo reads the source code definition of a symbol (function, type,
+// constant, etc.), identified either by symbolName or by a 1-indexed position in
+// filePath. With symbolName, filePath optionally limits the matches to that file.
+func GetContentInfo(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) (string, error) {
+	queryLocations, err := resolveQueryNameLocations(ctx, client, symbolName, filePath, line, column)
 	if err != nil {
-		return "", fmt.Errorf("could not open file: %v", err)
+		return "", err
+	}
+
+	if symbolName == "" {
+		return contentAt(ctx, client, queryLocations[0])
 	}
 
-	// Convert 1-indexed line/column to 0-indexed for LSP protocol
-	position := protocol.Position{
-		Line:      uint32(line - 1),
-		Character: uint32(column - 1),
+	var contents []string
+	for _, loc := range queryLocations {
+		content, err := contentAt(ctx, client, loc)
+		if err != nil {
+			toolsLogger.Error("Error getting content: %v", err)
+			continue
+		}
+		contents = append(contents, "---\n\n"+content+"\n")
 	}
 
-	location := protocol.Location{
-		URI: protocol.DocumentUri("file://" + filePath),
-		Range: protocol.Range{
-			Start: position,
-			End:   position,
-		},
+	if len(contents) == 0 {
+		return "", fmt.Errorf("could not read the definition of %s", symbolName)
 	}
 
+	return strings.Join(contents, ""), nil
+}
+
+// contentAt reads the definition surrounding the start of location
+func contentAt(ctx context.Context, client *lsp.Client, location protocol.Location) (string, error) {
 	definition, loc, symbol, err := GetFullDefinition(ctx, client, location)
 	if err != nil {
 		return "", err
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+)
+
+// CreateFile creates a file with the given content and opens it in the language
+// server, so that diagnostics for it are available right away. Edits the server
+// asks for before the file is created, e.g. to other files, are applied first.
+func CreateFile(ctx context.Context, client *lsp.Client, filePath, content string, overwrite bool) (string, error) {
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %v", err)
+	}
+
+	if info, err := os.Stat(filePath); err == nil {
+		if info.IsDir() {
+			return "", fmt.Errorf("%s is a directory", filePath)
+		}
+		if !overwrite {
+			return "", fmt.Errorf("file already exists: %s", filePath)
+		}
+	}
+
+	params := protocol.CreateFilesParams{
+		Files: []protocol.FileCreate{{
+			URI: "file://" + filePath,
+		}},
+	}
+	fileOperations := serverFileOperations(client.ServerCapabilities())
+
+	// Create the directory before changing anything, so that a bad path
+	// doesn't leave other files edited for a file that isn't created
+	removeCreatedDirs, err := makeParentDirs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// The edited files are restored if the edits or writing the file fail
+	var output strings.Builder
+	var edited map[string][]byte
+	if matchesFileOperationFilters(fileOperations.WillCreate, filePath, false) {
+		edit, err := client.WillCreateFiles(ctx, params)
+		if err != nil {
+			removeCreatedDirs()
+			return "", fmt.Errorf("failed to get edits for the new file: %v", err)
+		}
+		edited, err = readEditedFiles(edit)
+		if err != nil {
+			removeCreatedDirs()
+			return "", fmt.Errorf("failed to apply edits for the new file: %v", err)
+		}
+		if err := utilities.ApplyWorkspaceEdit(edit); err != nil {
+			restoreFiles(edited)
+			removeCreatedDirs()
+			return "", fmt.Errorf("failed to apply edits for the new file: %v", err)
+		}
+		if len(edit.Changes) > 0 || len(edit.DocumentChanges) > 0 {
+			output.WriteString("\nThe language server made these changes:\n")
+			output.WriteString(describeWorkspaceEdit(edit))
+		}
+	}
+
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		restoreFiles(edited)
+		removeCreatedDirs()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if matchesFileOperationFilters(fileOperations.DidCreate, filePath, false) {
+		if err := client.DidCreateFiles(ctx, params); err != nil {
+			toolsLogger.Error("Failed to notify server of created file: %v", err)
+		}
+	}
+
+	// An overwritten file may already be open
+	if client.IsFileOpen(filePath) {
+		err = client.NotifyChange(ctx, filePath)
+	} else {
+		err = client.OpenFile(ctx, filePath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	lineCount := len(strings.Split(strings.TrimSuffix(content, "\n"), "\n"))
+	if content == "" {
+		lineCount = 0
+	}
+
+	return fmt.Sprintf("Created %s (%d lines)\n%s", filePath, lineCount, output.String()), nil
+}
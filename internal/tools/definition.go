@@ -17,55 +17,16 @@ func ReadDefinition(ctx context.Context, client *lsp.Client, symbolName string)
 
 	var definitions []string
 	for _, symbol := range results {
-		kind := ""
-		container := ""
-
 		// Skip symbols that we are not looking for. workspace/symbol may return
-		// a large number of fuzzy matches. This handles BaseSymbolInformation
-		doesSymbolMatch := func(vKind protocol.SymbolKind, vContainerName string) bool {
-			thisName := symbol.GetName()
-
-			kind = fmt.Sprintf("Kind: %s\n", protocol.TableKindMap[vKind])
-			if vContainerName != "" {
-				container = fmt.Sprintf("Container Name: %s\n", vContainerName)
-			}
-
-			if thisName == symbolName {
-				return true
-			}
-
-			// Handle different matching strategies based on the search term
-			if strings.Contains(symbolName, ".") {
-				// For qualified names like "Type.Method", don't do fuzzy match
-
-			} else if vKind == protocol.Method {
-				// For methods, only match if the method name matches exactly Type.symbolName or Type::symbolName or symbolName
-				if strings.HasSuffix(thisName, "::"+symbolName) || strings.HasSuffix(symbolName, "::"+thisName) {
-					return true
-				}
-
-				if strings.HasSuffix(thisName, "."+symbolName) || strings.HasSuffix(symbolName, "."+thisName) {
-					return true
-				}
-			}
-
-			return false
+		// a large number of fuzzy matches.
+		if !matchesSymbolName(symbol, symbolName) {
+			continue
 		}
 
-		switch v := symbol.(type) {
-		case *protocol.SymbolInformation:
-			if !doesSymbolMatch(v.Kind, v.ContainerName) {
-				continue
-			}
-
-		case *protocol.WorkspaceSymbol:
-			if !doesSymbolMatch(v.Kind, v.ContainerName) {
-				continue
-			}
-		default:
-			if symbol.GetName() != symbolName {
-				continue
-			}
+		kind := fmt.Sprintf("Kind: %s\n", protocol.TableKindMap[symbol.GetKind()])
+		container := ""
+		if containerName := symbol.GetContainerName(); containerName != "" {
+			container = fmt.Sprintf("Container Name: %s\n", containerName)
 		}
 
 		toolsLogger.Debug("Found symbol: %s", symbol.GetName())
@@ -77,16 +38,20 @@ func ReadDefinition(ctx context.Context, client *lsp.Client, symbolName string)
 			continue
 		}
 
+		monikers := monikerSummary(ctx, client, loc)
+
 		banner := "---\n\n"
 		definition, loc, _, err := GetFullDefinition(ctx, client, loc)
 		locationInfo := fmt.Sprintf(
 			"Symbol: %s\n"+
 				"File: %s\n"+
-				kind+
-				container+
+				"%s%s%s"+
 				"Range: L%d:C%d - L%d:C%d\n\n",
 			symbol.GetName(),
 			strings.TrimPrefix(string(loc.URI), "file://"),
+			kind,
+			container,
+			monikers,
 			loc.Range.Start.Line+1,
 			loc.Range.Start.Character+1,
 			loc.Range.End.Line+1,
@@ -109,3 +74,29 @@ func ReadDefinition(ctx context.Context, client *lsp.Client, symbolName string)
 
 	return strings.Join(definitions, ""), nil
 }
+
+// matchesSymbolName reports whether a workspace symbol is the one named by
+// symbolName rather than one of the fuzzy matches returned by workspace/symbol
+func matchesSymbolName(symbol protocol.WorkspaceSymbolResult, symbolName string) bool {
+	thisName := symbol.GetName()
+	if thisName == symbolName {
+		return true
+	}
+
+	// Handle different matching strategies based on the search term
+	if strings.Contains(symbolName, ".") {
+		// For qualified names like "Type.Method", don't do fuzzy match
+
+	} else if symbol.GetKind() == protocol.Method {
+		// For methods, only match if the method name matches exactly Type.symbolName or Type::symbolName or symbolName
+		if strings.HasSuffix(thisName, "::"+symbolName) || strings.HasSuffix(symbolName, "::"+thisName) {
+			return true
+		}
+
+		if strings.HasSuffix(thisName, "."+symbolName) || strings.HasSuffix(symbolName, "."+thisName) {
+			return true
+		}
+	}
+
+	return false
+}
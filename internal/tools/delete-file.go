@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+)
+
+// DeleteFile deletes a file, or a directory if recursive is set. Edits the
+// server asks for before the deletion are applied first, and the cached
+// diagnostics of the deleted files are cleared.
+func DeleteFile(ctx context.Context, client *lsp.Client, filePath string, recursive bool) (string, error) {
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %v", err)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to access %s: %w", filePath, err)
+	}
+	if info.IsDir() && !recursive {
+		return "", fmt.Errorf("%s is a directory, set recursive to delete it and its contents", filePath)
+	}
+
+	params := protocol.DeleteFilesParams{
+		Files: []protocol.FileDelete{{
+			URI: "file://" + filePath,
+		}},
+	}
+	fileOperations := serverFileOperations(client.ServerCapabilities())
+
+	// The edited files are restored if the edits or the deletion fail
+	var output strings.Builder
+	var edited map[string][]byte
+	if matchesFileOperationFilters(fileOperations.WillDelete, filePath, info.IsDir()) {
+		edit, err := client.WillDeleteFiles(ctx, params)
+		if err != nil {
+			return "", fmt.Errorf("failed to get edits for the deletion: %v", err)
+		}
+		edited, err = readEditedFiles(edit)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply edits for the deletion: %v", err)
+		}
+		if err := utilities.ApplyWorkspaceEdit(edit); err != nil {
+			restoreFiles(edited)
+			return "", fmt.Errorf("failed to apply edits for the deletion: %v", err)
+		}
+		if len(edit.Changes) > 0 || len(edit.DocumentChanges) > 0 {
+			output.WriteString("\nThe language server made these changes:\n")
+			output.WriteString(describeWorkspaceEdit(edit))
+		}
+	}
+
+	if err := client.CloseFilesUnder(ctx, filePath); err != nil {
+		toolsLogger.Error("Failed to close deleted files: %v", err)
+	}
+
+	if info.IsDir() {
+		err = os.RemoveAll(filePath)
+	} else {
+		err = os.Remove(filePath)
+	}
+	if err != nil {
+		restoreFiles(edited)
+		return "", fmt.Errorf("failed to delete %s: %w", filePath, err)
+	}
+
+	client.ClearDiagnostics(filePath)
+
+	if matchesFileOperationFilters(fileOperations.DidDelete, filePath, info.IsDir()) {
+		if err := client.DidDeleteFiles(ctx, params); err != nil {
+			toolsLogger.Error("Failed to notify server of deleted file: %v", err)
+		}
+	}
+
+	return fmt.Sprintf("Deleted %s\n%s", filePath, output.String()), nil
+}
@@ -60,28 +60,7 @@ func GetDiagnosticsForFile(ctx context.Context, client *lsp.Client, filePath str
 	var diagLocations []protocol.Location
 
 	for _, diag := range diagnostics {
-		severity := getSeverityString(diag.Severity)
-		location := fmt.Sprintf("L%d:C%d",
-			diag.Range.Start.Line+1,
-			diag.Range.Start.Character+1)
-
-		summary := fmt.Sprintf("%s at %s: %s",
-			severity,
-			location,
-			diag.Message)
-
-		// Add source and code if available
-		if diag.Source != "" {
-			summary += fmt.Sprintf(" (Source: %s", diag.Source)
-			if diag.Code != nil {
-				summary += fmt.Sprintf(", Code: %v", diag.Code)
-			}
-			summary += ")"
-		} else if diag.Code != nil {
-			summary += fmt.Sprintf(" (Code: %v)", diag.Code)
-		}
-
-		diagSummaries = append(diagSummaries, summary)
+		diagSummaries = append(diagSummaries, formatDiagnostic(diag))
 
 		// Create a location for this diagnostic to use with line ranges
 		diagLocations = append(diagLocations, protocol.Location{
@@ -149,3 +128,47 @@ func getSeverityString(severity protocol.DiagnosticSeverity) string {
 		return "UNKNOWN"
 	}
 }
+
+// formatDiagnostic formats a diagnostic on one line with its severity,
+// position, message, source and code
+func formatDiagnostic(diag protocol.Diagnostic) string {
+	summary := fmt.Sprintf("%s at L%d:C%d: %s",
+		getSeverityString(diagnosticSeverity(diag)),
+		diag.Range.Start.Line+1,
+		diag.Range.Start.Character+1,
+		diag.Message)
+
+	// Add source and code if available
+	if diag.Source != "" {
+		summary += fmt.Sprintf(" (Source: %s", diag.Source)
+		if diag.Code != nil {
+			summary += fmt.Sprintf(", Code: %s", diagnosticCode(diag.Code))
+		}
+		summary += ")"
+	} else if diag.Code != nil {
+		summary += fmt.Sprintf(" (Code: %s)", diagnosticCode(diag.Code))
+	}
+
+	return summary
+}
+
+// diagnosticSeverity returns the severity of a diagnostic. Servers may leave
+// it out, in which case it is treated as an error like editors do.
+func diagnosticSeverity(diag protocol.Diagnostic) protocol.DiagnosticSeverity {
+	if diag.Severity == 0 {
+		return protocol.SeverityError
+	}
+	return diag.Severity
+}
+
+// diagnosticCode formats a diagnostic code. Numeric codes are decoded from
+// JSON as float64, which fmt would print as e.g. 1e+06.
+func diagnosticCode(code any) string {
+	switch v := code.(type) {
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return fmt.Sprint(code)
+}
@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDiagnostic(t *testing.T) {
+	diag := protocol.Diagnostic{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: 4, Character: 2},
+		},
+		Severity: protocol.SeverityWarning,
+		Source:   "ts",
+		Code:     float64(2304),
+		Message:  "Cannot find name 'x'.",
+	}
+	assert.Equal(t, "WARNING at L5:C3: Cannot find name 'x'. (Source: ts, Code: 2304)", formatDiagnostic(diag))
+
+	// Servers may leave out the severity and source
+	diag.Severity = 0
+	diag.Source = ""
+	diag.Code = "unused"
+	assert.Equal(t, "ERROR at L5:C3: Cannot find name 'x'. (Code: unused)", formatDiagnostic(diag))
+}
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// GetDocumentSymbols returns an outline of the symbols defined in a file.
+// maxDepth limits how deeply nested symbols are shown (0 for no limit) and
+// kinds limits the output to symbols of the given kinds. Parents of matching
+// symbols are kept so that the hierarchy stays readable.
+func GetDocumentSymbols(ctx context.Context, client *lsp.Client, filePath string, maxDepth int, kindNames []string) (string, error) {
+	kinds, err := parseSymbolKinds(kindNames)
+	if err != nil {
+		return "", err
+	}
+
+	err = client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	symResult, err := client.DocumentSymbol(ctx, protocol.DocumentSymbolParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: protocol.DocumentUri("file://" + filePath),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get document symbols: %w", err)
+	}
+
+	symbols, err := symResult.Results()
+	if err != nil {
+		return "", fmt.Errorf("failed to process document symbols: %w", err)
+	}
+
+	lines, count := formatDocumentOutline(symbols, maxDepth, kinds)
+
+	if count == 0 {
+		return fmt.Sprintf("No symbols found in %s", filePath), nil
+	}
+
+	return fmt.Sprintf("%s\nSymbols: %d\n\n%s\n", filePath, count, strings.Join(lines, "\n")), nil
+}
+
+// formatDocumentOutline formats document symbols as an indented outline with
+// the filters of GetDocumentSymbols, and counts the symbols matching them
+func formatDocumentOutline(symbols []protocol.DocumentSymbolResult, maxDepth int, kinds []protocol.SymbolKind) ([]string, int) {
+	var lines []string
+	count := 0
+
+	matchesKind := func(sym protocol.DocumentSymbolResult) bool {
+		return len(kinds) == 0 || slices.Contains(kinds, sym.GetKind())
+	}
+
+	// writeSymbol returns true if the symbol or any of its children were written
+	var writeSymbol func(sym protocol.DocumentSymbolResult, depth int) bool
+	writeSymbol = func(sym protocol.DocumentSymbolResult, depth int) bool {
+		if maxDepth > 0 && depth >= maxDepth {
+			return false
+		}
+
+		// Remember where this symbol goes so that it can be inserted before its
+		// children if only the children match the kind filter
+		insertAt := len(lines)
+
+		var childMatched bool
+		if ds, ok := sym.(*protocol.DocumentSymbol); ok {
+			for i := range ds.Children {
+				if writeSymbol(&ds.Children[i], depth+1) {
+					childMatched = true
+				}
+			}
+		}
+
+		if !matchesKind(sym) && !childMatched {
+			return false
+		}
+
+		lines = slices.Insert(lines, insertAt, formatDocumentSymbol(sym, depth))
+		if matchesKind(sym) {
+			count++
+		}
+		return true
+	}
+
+	for _, sym := range symbols {
+		writeSymbol(sym, 0)
+	}
+
+	return lines, count
+}
+
+// formatDocumentSymbol formats a single line of the outline, e.g.
+// "  Method [Method] L12-L14: func() string (deprecated)"
+func formatDocumentSymbol(sym protocol.DocumentSymbolResult, depth int) string {
+	var line strings.Builder
+	line.WriteString(strings.Repeat("  ", depth))
+	fmt.Fprintf(&line, "%s [%s]", sym.GetName(), protocol.TableKindMap[sym.GetKind()])
+
+	rng := sym.GetRange()
+	if rng.Start.Line == rng.End.Line {
+		fmt.Fprintf(&line, " L%d", rng.Start.Line+1)
+	} else {
+		fmt.Fprintf(&line, " L%d-L%d", rng.Start.Line+1, rng.End.Line+1)
+	}
+
+	var detail string
+	var deprecated bool
+	switch v := sym.(type) {
+	case *protocol.DocumentSymbol:
+		detail = v.Detail
+		deprecated = v.Deprecated || slices.Contains(v.Tags, protocol.DeprecatedSymbol)
+	case *protocol.SymbolInformation:
+		// Flat results have no hierarchy, so show the container instead
+		if v.ContainerName != "" {
+			detail = "in " + v.ContainerName
+		}
+		deprecated = v.Deprecated || slices.Contains(v.Tags, protocol.DeprecatedSymbol)
+	}
+
+	if detail != "" {
+		// Details can span lines (e.g. full struct types), keep the outline compact
+		detail, _, _ = strings.Cut(detail, "\n")
+		fmt.Fprintf(&line, ": %s", detail)
+	}
+	if deprecated {
+		line.WriteString(" (deprecated)")
+	}
+
+	return line.String()
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDocumentOutline(t *testing.T) {
+	lines := func(start, end uint32) protocol.Range {
+		return protocol.Range{Start: protocol.Position{Line: start - 1}, End: protocol.Position{Line: end - 1}}
+	}
+
+	symbols := []protocol.DocumentSymbolResult{
+		&protocol.DocumentSymbol{
+			Name:  "Server",
+			Kind:  protocol.Class,
+			Range: lines(1, 20),
+			Children: []protocol.DocumentSymbol{
+				{Name: "Start", Kind: protocol.Method, Detail: "func() error", Range: lines(2, 5)},
+				{Name: "port", Kind: protocol.Field, Range: lines(6, 6)},
+			},
+		},
+		&protocol.DocumentSymbol{
+			Name:  "helper",
+			Kind:  protocol.Function,
+			Range: lines(22, 25),
+			Children: []protocol.DocumentSymbol{
+				{Name: "count", Kind: protocol.Variable, Range: lines(23, 23)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		maxDepth  int
+		kinds     []protocol.SymbolKind
+		wantLines []string
+		wantCount int
+	}{
+		{
+			name: "all symbols",
+			wantLines: []string{
+				"Server [Class] L1-L20",
+				"  Start [Method] L2-L5: func() error",
+				"  port [Field] L6",
+				"helper [Function] L22-L25",
+				"  count [Variable] L23",
+			},
+			wantCount: 5,
+		},
+		{
+			name:      "top level only",
+			maxDepth:  1,
+			wantLines: []string{"Server [Class] L1-L20", "helper [Function] L22-L25"},
+			wantCount: 2,
+		},
+		{
+			name:      "kind filter keeps parents",
+			kinds:     []protocol.SymbolKind{protocol.Method},
+			wantLines: []string{"Server [Class] L1-L20", "  Start [Method] L2-L5: func() error"},
+			wantCount: 1,
+		},
+		{
+			name:      "kind filter below max depth",
+			maxDepth:  1,
+			kinds:     []protocol.SymbolKind{protocol.Method},
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLines, gotCount := formatDocumentOutline(symbols, tt.maxDepth, tt.kinds)
+			assert.Equal(t, tt.wantLines, gotLines)
+			assert.Equal(t, tt.wantCount, gotCount)
+		})
+	}
+}
+
+func TestFormatDocumentSymbolInformation(t *testing.T) {
+	symbol := &protocol.SymbolInformation{
+		Name:          "main",
+		Kind:          protocol.Function,
+		ContainerName: "app",
+		Deprecated:    true,
+		Location:      protocol.Location{Range: protocol.Range{End: protocol.Position{Line: 2}}},
+	}
+	assert.Equal(t, "  main [Function] L1-L3: in app (deprecated)", formatDocumentSymbol(symbol, 1))
+}
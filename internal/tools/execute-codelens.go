@@ -3,21 +3,17 @@ package tools
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/isaacphi/mcp-language-server/internal/lsp"
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
 )
 
-// ExecuteCodeLens executes a specific code lens command from a file.
+// ExecuteCodeLens executes a specific code lens command from a file and reports
+// its effects: the edits the server applied and the messages it sent.
 func ExecuteCodeLens(ctx context.Context, client *lsp.Client, filePath string, index int) (string, error) {
-	// Open the file
-	err := client.OpenFile(ctx, filePath)
-	if err != nil {
-		return "", fmt.Errorf("could not open file: %v", err)
+	if err := openFileAndWait(ctx, client, filePath); err != nil {
+		return "", err
 	}
-	// TODO: find a more appropriate way to wait
-	time.Sleep(time.Second)
 
 	// Get code lenses
 	docIdentifier := protocol.TextDocumentIdentifier{
@@ -56,13 +52,11 @@ func ExecuteCodeLens(ctx context.Context, client *lsp.Client, filePath string, i
 	}
 
 	// Execute the command
-	_, err = client.ExecuteCommand(ctx, protocol.ExecuteCommandParams{
-		Command:   lens.Command.Command,
-		Arguments: lens.Command.Arguments,
-	})
+	result, err := runCommand(ctx, client, lens.Command.Command, lens.Command.Arguments)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute code lens command: %v", err)
 	}
 
-	return fmt.Sprintf("Successfully executed code lens command: %s", lens.Command.Title), nil
+	return fmt.Sprintf("Executed code lens command: %s (%s)\n\n%s",
+		lens.Command.Title, lens.Command.Command, formatCommandResult(result)), nil
 }
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+)
+
+// ListCommands lists the commands the language server advertises in its
+// executeCommandProvider capability, optionally only those containing filter
+func ListCommands(client *lsp.Client, filter string) (string, error) {
+	commands := serverCommands(client)
+	if len(commands) == 0 {
+		return "The language server does not advertise any commands", nil
+	}
+
+	var matched []string
+	for _, command := range commands {
+		if filter == "" || strings.Contains(strings.ToLower(command), strings.ToLower(filter)) {
+			matched = append(matched, command)
+		}
+	}
+	if len(matched) == 0 {
+		return fmt.Sprintf("No commands matching '%s' out of %d advertised by the language server", filter, len(commands)), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("The language server advertises %d commands", len(commands)))
+	if filter != "" {
+		output.WriteString(fmt.Sprintf(", %d matching '%s'", len(matched), filter))
+	}
+	output.WriteString(":\n")
+	for _, command := range matched {
+		output.WriteString(command + "\n")
+	}
+	return output.String(), nil
+}
+
+// ExecuteCommand runs a command the language server advertises, with arguments
+// given as JSON, and reports what it returned, the edits it applied and the
+// messages the server sent while it ran
+func ExecuteCommand(ctx context.Context, client *lsp.Client, command, arguments string) (string, error) {
+	commands := serverCommands(client)
+	if len(commands) > 0 && !slices.Contains(commands, command) {
+		return "", fmt.Errorf("the language server does not advertise the command '%s', use list_commands to see the available commands", command)
+	}
+
+	args, err := parseCommandArguments(arguments)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := runCommand(ctx, client, command, args)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute command: %v", err)
+	}
+
+	return fmt.Sprintf("Executed command: %s\n\n%s", command, formatCommandResult(result)), nil
+}
+
+// serverCommands returns the sorted commands the server advertises
+func serverCommands(client *lsp.Client) []string {
+	provider := client.ServerCapabilities().ExecuteCommandProvider
+	if provider == nil {
+		return nil
+	}
+
+	commands := slices.Clone(provider.Commands)
+	sort.Strings(commands)
+	return commands
+}
+
+// parseCommandArguments parses the arguments of a command. They are a JSON
+// array with one element per argument. Any other JSON value is taken to be the
+// only argument, since most commands take a single object.
+func parseCommandArguments(arguments string) ([]json.RawMessage, error) {
+	arguments = strings.TrimSpace(arguments)
+	if arguments == "" {
+		return nil, nil
+	}
+
+	if !json.Valid([]byte(arguments)) {
+		return nil, fmt.Errorf("arguments must be valid JSON: %s", arguments)
+	}
+
+	if strings.HasPrefix(arguments, "[") {
+		var args []json.RawMessage
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %v", err)
+		}
+		return args, nil
+	}
+
+	return []json.RawMessage{json.RawMessage(arguments)}, nil
+}
@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommandArguments(t *testing.T) {
+	args, err := parseCommandArguments("")
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	args, err = parseCommandArguments(`[{"URI": "file:///project/go.mod"}, true]`)
+	require.NoError(t, err)
+	assert.Equal(t, []json.RawMessage{
+		json.RawMessage(`{"URI": "file:///project/go.mod"}`),
+		json.RawMessage(`true`),
+	}, args)
+
+	// A single object is the only argument
+	args, err = parseCommandArguments(` {"ImportPath": "strings"} `)
+	require.NoError(t, err)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`{"ImportPath": "strings"}`)}, args)
+
+	_, err = parseCommandArguments(`{"ImportPath": `)
+	assert.Error(t, err)
+}
@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+)
+
+// FormatRange is an inclusive, 1-indexed range of lines to format
+type FormatRange struct {
+	StartLine int
+	EndLine   int
+}
+
+// FormatOptions controls how FormatFile formats a file
+type FormatOptions struct {
+	TabSize                int           // Size of a tab in spaces
+	InsertSpaces           bool          // Prefer spaces over tabs
+	TrimTrailingWhitespace bool          // Trim trailing whitespace on a line
+	Ranges                 []FormatRange // Only format these lines. Empty means the whole file
+	DryRun                 bool          // Return a unified diff instead of writing the file
+}
+
+// ParseFormatRanges parses line ranges written as "10-20", or "15" for a
+// single line
+func ParseFormatRanges(specs []string) ([]FormatRange, error) {
+	ranges := make([]FormatRange, 0, len(specs))
+	for _, spec := range specs {
+		start, end, found := strings.Cut(strings.TrimSpace(spec), "-")
+		startLine, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q: %v", spec, err)
+		}
+		endLine := startLine
+		if found {
+			endLine, err = strconv.Atoi(strings.TrimSpace(end))
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q: %v", spec, err)
+			}
+		}
+		ranges = append(ranges, FormatRange{StartLine: startLine, EndLine: endLine})
+	}
+	return ranges, nil
+}
+
+// FormatFile formats a file, or ranges of lines in it, using the language
+// server's formatter and reports how many lines changed
+func FormatFile(ctx context.Context, client *lsp.Client, filePath string, opts FormatOptions) (string, error) {
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	uri := protocol.DocumentUri("file://" + filePath)
+	docIdentifier := protocol.TextDocumentIdentifier{
+		URI: uri,
+	}
+	options := protocol.FormattingOptions{
+		TabSize:                uint32(opts.TabSize),
+		InsertSpaces:           opts.InsertSpaces,
+		TrimTrailingWhitespace: opts.TrimTrailingWhitespace,
+	}
+
+	var edits []protocol.TextEdit
+	if len(opts.Ranges) == 0 {
+		edits, err = client.Formatting(ctx, protocol.DocumentFormattingParams{
+			TextDocument: docIdentifier,
+			Options:      options,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to format file: %v", err)
+		}
+	} else {
+		edits, err = formatRanges(ctx, client, docIdentifier, content, opts.Ranges, options)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	newContent, err := utilities.ApplyTextEditsToContent(content, edits)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply formatting edits: %v", err)
+	}
+
+	changedLines := utilities.ChangedLines(content, newContent)
+	if changedLines == 0 {
+		return fmt.Sprintf("%s is already formatted", filePath), nil
+	}
+
+	if opts.DryRun {
+		diff, err := utilities.UnifiedDiff(filePath, content, newContent)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Formatting would change %d lines in %s:\n\n%s", changedLines, filePath, diff), nil
+	}
+
+	if err := os.WriteFile(filePath, newContent, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	// Keep the server's copy of the open file in sync
+	if err := client.NotifyChange(ctx, filePath); err != nil {
+		toolsLogger.Error("Failed to notify change: %v", err)
+	}
+
+	return fmt.Sprintf("Successfully formatted %s. %d lines changed.", filePath, changedLines), nil
+}
+
+// formatRanges requests formatting edits for ranges of lines, in a single
+// request when the server supports formatting multiple ranges at once
+func formatRanges(
+	ctx context.Context, client *lsp.Client, docIdentifier protocol.TextDocumentIdentifier,
+	content []byte, lineRanges []FormatRange, options protocol.FormattingOptions,
+) ([]protocol.TextEdit, error) {
+	if err := checkRangesDisjoint(lineRanges); err != nil {
+		return nil, err
+	}
+
+	ranges := make([]protocol.Range, len(lineRanges))
+	for i, r := range lineRanges {
+		var err error
+		ranges[i], err = formatLineRange(content, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ranges) > 1 && supportsRangesFormatting(client.ServerCapabilities()) {
+		edits, err := client.RangesFormatting(ctx, protocol.DocumentRangesFormattingParams{
+			TextDocument: docIdentifier,
+			Ranges:       ranges,
+			Options:      options,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to format ranges: %v", err)
+		}
+		return edits, nil
+	}
+
+	// Format one range at a time. The ranges don't overlap, so the edits for
+	// all of them can be applied together.
+	var edits []protocol.TextEdit
+	for i, rng := range ranges {
+		rangeEdits, err := client.RangeFormatting(ctx, protocol.DocumentRangeFormattingParams{
+			TextDocument: docIdentifier,
+			Range:        rng,
+			Options:      options,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to format range L%d-L%d: %v", lineRanges[i].StartLine, lineRanges[i].EndLine, err)
+		}
+		edits = append(edits, rangeEdits...)
+	}
+	return edits, nil
+}
+
+// formatLineRange converts an inclusive line range to a protocol.Range that
+// covers the full lines
+func formatLineRange(content []byte, r FormatRange) (protocol.Range, error) {
+	lines := strings.Split(string(content), "\n")
+
+	if r.StartLine < 1 || r.EndLine < r.StartLine {
+		return protocol.Range{}, fmt.Errorf("invalid range: L%d-L%d", r.StartLine, r.EndLine)
+	}
+	if r.EndLine > len(lines) {
+		return protocol.Range{}, fmt.Errorf("end line %d is beyond end of file (%d lines)", r.EndLine, len(lines))
+	}
+
+	end := protocol.Position{Line: uint32(r.EndLine)}
+	if r.EndLine == len(lines) {
+		// There is no next line to end at, so end at the end of the last line,
+		// counted in UTF-16 code units like protocol positions
+		end = protocol.Position{
+			Line:      uint32(r.EndLine - 1),
+			Character: uint32(len(utf16.Encode([]rune(strings.TrimSuffix(lines[r.EndLine-1], "\r"))))),
+		}
+	}
+
+	return protocol.Range{
+		Start: protocol.Position{Line: uint32(r.StartLine - 1)},
+		End:   end,
+	}, nil
+}
+
+// checkRangesDisjoint returns an error if any two line ranges overlap, since
+// their edits can't be applied together
+func checkRangesDisjoint(lineRanges []FormatRange) error {
+	sorted := slices.Clone(lineRanges)
+	slices.SortFunc(sorted, func(a, b FormatRange) int {
+		return a.StartLine - b.StartLine
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].StartLine <= sorted[i-1].EndLine {
+			return fmt.Errorf("ranges L%d-L%d and L%d-L%d overlap",
+				sorted[i-1].StartLine, sorted[i-1].EndLine, sorted[i].StartLine, sorted[i].EndLine)
+		}
+	}
+	return nil
+}
+
+func supportsRangesFormatting(capabilities protocol.ServerCapabilities) bool {
+	if capabilities.DocumentRangeFormattingProvider == nil {
+		return false
+	}
+	options, ok := capabilities.DocumentRangeFormattingProvider.Value.(protocol.DocumentRangeFormattingOptions)
+	return ok && options.RangesSupport
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormatRanges(t *testing.T) {
+	ranges, err := ParseFormatRanges([]string{"10-20", " 15 ", "3 - 4"})
+	assert.NoError(t, err)
+	assert.Equal(t, []FormatRange{{10, 20}, {15, 15}, {3, 4}}, ranges)
+
+	_, err = ParseFormatRanges([]string{"ten-20"})
+	assert.Error(t, err)
+}
+
+func TestFormatLineRange(t *testing.T) {
+	content := []byte("package main\r\n\r\nvar s = \"héllo 😀\"")
+
+	// Ranges end at the start of the line after them
+	rng, err := formatLineRange(content, FormatRange{StartLine: 1, EndLine: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, protocol.Range{
+		Start: protocol.Position{Line: 0},
+		End:   protocol.Position{Line: 2},
+	}, rng)
+
+	// The last line ends at its length in UTF-16 code units
+	rng, err = formatLineRange(content, FormatRange{StartLine: 3, EndLine: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, protocol.Position{Line: 2, Character: 18}, rng.End)
+
+	_, err = formatLineRange(content, FormatRange{StartLine: 2, EndLine: 4})
+	assert.Error(t, err)
+}
+
+func TestCheckRangesDisjoint(t *testing.T) {
+	assert.NoError(t, checkRangesDisjoint([]FormatRange{{10, 20}, {1, 5}, {21, 21}}))
+	assert.Error(t, checkRangesDisjoint([]FormatRange{{10, 20}, {1, 5}, {20, 25}}))
+	assert.Error(t, checkRangesDisjoint([]FormatRange{{10, 20}, {12, 12}}))
+}
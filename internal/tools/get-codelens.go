@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/isaacphi/mcp-language-server/internal/lsp"
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
@@ -12,12 +11,9 @@ import (
 
 // GetCodeLens retrieves code lens hints for a given file location
 func GetCodeLens(ctx context.Context, client *lsp.Client, filePath string) (string, error) {
-	err := client.OpenFile(ctx, filePath)
-	if err != nil {
-		return "", fmt.Errorf("could not open file: %v", err)
+	if err := openFileAndWait(ctx, client, filePath); err != nil {
+		return "", err
 	}
-	// TODO: find a more appropriate way to wait
-	time.Sleep(time.Second)
 
 	// Create document identifier
 	docIdentifier := protocol.TextDocumentIdentifier{
@@ -37,6 +33,19 @@ func GetCodeLens(ctx context.Context, client *lsp.Client, filePath string) (stri
 		return "No code lens providers available for this file.", nil
 	}
 
+	// Resolve lenses without a command, so their titles can be shown
+	for i, lens := range codeLensResult {
+		if lens.Command != nil {
+			continue
+		}
+		resolvedLens, err := client.ResolveCodeLens(ctx, lens)
+		if err != nil {
+			toolsLogger.Debug("Failed to resolve code lens %d: %v", i+1, err)
+			continue
+		}
+		codeLensResult[i] = resolvedLens
+	}
+
 	// Format the code lens results
 	var output strings.Builder
 	output.WriteString(fmt.Sprintf("Code Lens results for %s:\n\n", filePath))
@@ -55,7 +64,7 @@ func GetCodeLens(ctx context.Context, client *lsp.Client, filePath string) (stri
 			if lens.Command.Arguments != nil {
 				output.WriteString("    Arguments:\n")
 				for _, arg := range lens.Command.Arguments {
-					output.WriteString(fmt.Sprintf("%s\n", arg))
+					output.WriteString(fmt.Sprintf("      %s\n", arg))
 				}
 			}
 		}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// GetHighlights finds the occurrences within a file of the symbol at a position,
+// marking each one as a read or a write of it where the server can tell, and
+// shows the surrounding code
+func GetHighlights(ctx context.Context, client *lsp.Client, filePath string, line, column int) (string, error) {
+	// Get context lines from environment variable
+	contextLines := 5
+	if envLines := os.Getenv("LSP_CONTEXT_LINES"); envLines != "" {
+		if val, err := strconv.Atoi(envLines); err == nil && val >= 0 {
+			contextLines = val
+		}
+	}
+
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	uri := protocol.DocumentUri("file://" + filePath)
+
+	// Convert 1-indexed line/column to 0-indexed for LSP protocol
+	highlights, err := client.DocumentHighlight(ctx, protocol.DocumentHighlightParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: uri,
+			},
+			Position: protocol.Position{
+				Line:      uint32(line - 1),
+				Character: uint32(column - 1),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get highlights: %v", err)
+	}
+
+	if len(highlights) == 0 {
+		return fmt.Sprintf("No highlights found at %s L%d:C%d", filePath, line, column), nil
+	}
+
+	sort.SliceStable(highlights, func(i, j int) bool {
+		if highlights[i].Range.Start.Line != highlights[j].Range.Start.Line {
+			return highlights[i].Range.Start.Line < highlights[j].Range.Start.Line
+		}
+		return highlights[i].Range.Start.Character < highlights[j].Range.Start.Character
+	})
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+
+	counts := make(map[string]int)
+	var occurrences []string
+	locations := make([]protocol.Location, len(highlights))
+	for i, highlight := range highlights {
+		kind := highlightKindString(highlight.Kind)
+		counts[kind]++
+		occurrences = append(occurrences, fmt.Sprintf("L%d:C%d %s",
+			highlight.Range.Start.Line+1, highlight.Range.Start.Character+1, kind))
+		locations[i] = protocol.Location{URI: uri, Range: highlight.Range}
+	}
+
+	var summary []string
+	for _, kind := range []string{"Write", "Read", "Text"} {
+		if counts[kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind], strings.ToLower(kind)))
+		}
+	}
+
+	linesToShow, err := GetLineRangesToDisplay(ctx, client, locations, len(lines), contextLines)
+	if err != nil {
+		return "", err
+	}
+	lineRanges := ConvertLinesToRanges(linesToShow, len(lines))
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Highlights at %s L%d:C%d\n", filePath, line, column))
+	output.WriteString(fmt.Sprintf("Occurrences: %d (%s)\n", len(highlights), strings.Join(summary, ", ")))
+	for _, occurrence := range occurrences {
+		output.WriteString("  " + occurrence + "\n")
+	}
+	output.WriteString("\n" + FormatLinesWithRanges(lines, lineRanges))
+
+	return output.String(), nil
+}
+
+// highlightKindString returns the name of a highlight kind. Servers that
+// leave the kind out mean Text.
+func highlightKindString(kind protocol.DocumentHighlightKind) string {
+	switch kind {
+	case protocol.Read:
+		return "Read"
+	case protocol.Write:
+		return "Write"
+	default:
+		return "Text"
+	}
+}
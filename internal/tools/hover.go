@@ -10,30 +10,53 @@ import (
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
 )
 
-// GetHoverInfo retrieves hover information (type, documentation) for a symbol at the specified position
-func GetHoverInfo(ctx context.Context, client *lsp.Client, filePath string, line, column int) (string, error) {
-	// Open the file if not already open
-	err := client.OpenFile(ctx, filePath)
+// GetHoverInfo retrieves hover information (type, documentation) for a symbol,
+// identified either by symbolName or by a 1-indexed position in filePath. With
+// symbolName, filePath optionally limits the matches to that file.
+func GetHoverInfo(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) (string, error) {
+	queryLocations, err := resolveQueryNameLocations(ctx, client, symbolName, filePath, line, column)
 	if err != nil {
-		return "", fmt.Errorf("could not open file: %v", err)
+		return "", err
 	}
 
-	params := protocol.HoverParams{}
+	if symbolName == "" {
+		return hoverAt(ctx, client, queryLocations[0])
+	}
+
+	// A name can match several symbols, so label each one with where it is
+	var result strings.Builder
+	for _, loc := range queryLocations {
+		fmt.Fprintf(&result, "---\n\n%s L%d:C%d\n\n",
+			strings.TrimPrefix(string(loc.URI), "file://"), loc.Range.Start.Line+1, loc.Range.Start.Character+1)
 
-	// Convert 1-indexed line/column to 0-indexed for LSP protocol
-	position := protocol.Position{
-		Line:      uint32(line - 1),
-		Character: uint32(column - 1),
+		hover, err := hoverAt(ctx, client, loc)
+		if err != nil {
+			fmt.Fprintf(&result, "Error: %v\n\n", err)
+			continue
+		}
+		result.WriteString(strings.TrimRight(hover, "\n") + "\n\n")
 	}
-	uri := protocol.DocumentUri("file://" + filePath)
-	params.TextDocument = protocol.TextDocumentIdentifier{
-		URI: uri,
+
+	return result.String(), nil
+}
+
+// hoverAt requests hover information for the position at the start of loc
+func hoverAt(ctx context.Context, client *lsp.Client, loc protocol.Location) (string, error) {
+	position := loc.Range.Start
+	uri := loc.URI
+	params := protocol.HoverParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: uri,
+			},
+			Position: position,
+		},
 	}
-	params.Position = position
 
 	// Execute the hover request
 	// - some LSP (rust) will return "content modified", so retry it
 	var hoverResult protocol.Hover
+	var err error
 	for i := range 3 {
 		hoverResult, err = client.Hover(ctx, params)
 		if err == nil {
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// FindImplementations finds the concrete implementations of an interface, abstract
+// type or method, identified either by symbolName or by a 1-indexed position in filePath.
+// Each implementation is returned with its full source.
+func FindImplementations(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) (string, error) {
+	return readNavigationTargets(ctx, client, symbolName, filePath, line, column, "implementations",
+		func(params protocol.TextDocumentPositionParams) ([]protocol.Location, error) {
+			result, err := client.Implementation(ctx, protocol.ImplementationParams{
+				TextDocumentPositionParams: params,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result.Locations()
+		})
+}
+
+// readNavigationTargets sends a position based navigation request (implementation,
+// typeDefinition, declaration, ...) for a symbol and returns the full source of
+// every target it points to
+func readNavigationTargets(
+	ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int, targetName string,
+	request func(params protocol.TextDocumentPositionParams) ([]protocol.Location, error),
+) (string, error) {
+	queryLocations, err := resolveQueryLocations(ctx, client, symbolName, filePath, line, column)
+	if err != nil {
+		return "", err
+	}
+
+	// A failure for one match of a name shouldn't hide the others
+	var locations []protocol.Location
+	var firstErr error
+	for _, loc := range queryLocations {
+		targets, err := request(protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: loc.URI,
+			},
+			Position: loc.Range.Start,
+		})
+		if err != nil {
+			toolsLogger.Error("Failed to get %s at %s: %v", targetName, formatQueryLocation(loc), err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		locations = append(locations, targets...)
+	}
+
+	if len(locations) == 0 {
+		if firstErr != nil {
+			return "", fmt.Errorf("failed to get %s: %v", targetName, firstErr)
+		}
+		return fmt.Sprintf("No %s found for %s", targetName, describeQueryTarget(symbolName, filePath, line, column)), nil
+	}
+
+	locations = sortLocations(locations)
+
+	var definitions []string
+	for _, loc := range locations {
+		definition, err := formatDefinitionAt(ctx, client, loc)
+		if err != nil {
+			// Still report the location even if the body can't be read
+			toolsLogger.Error("Error getting definition for %s: %v", targetName, err)
+			definition = fmt.Sprintf("File: %s\nRange: L%d:C%d - L%d:C%d\n",
+				strings.TrimPrefix(string(loc.URI), "file://"),
+				loc.Range.Start.Line+1,
+				loc.Range.Start.Character+1,
+				loc.Range.End.Line+1,
+				loc.Range.End.Character+1,
+			)
+		}
+		definitions = append(definitions, "---\n\n"+definition+"\n")
+	}
+
+	return fmt.Sprintf("Found %d %s\n\n%s", len(locations), targetName, strings.Join(definitions, "")), nil
+}
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// Markers around inlay hints, so that they can't be mistaken for code
+const (
+	inlayHintStart = "«"
+	inlayHintEnd   = "»"
+)
+
+// GetInlayHints returns lines of a file with the inlay hints the language server
+// provides for them, such as inferred types and parameter names, inserted inline
+func GetInlayHints(ctx context.Context, client *lsp.Client, filePath string, startLine, endLine int) (string, error) {
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+
+	if startLine < 1 {
+		startLine = 1
+	}
+	if endLine < 1 || endLine > len(lines) {
+		endLine = len(lines)
+	}
+	if startLine > endLine {
+		return "", fmt.Errorf("invalid line range: L%d-L%d", startLine, endLine)
+	}
+
+	uri := protocol.DocumentUri("file://" + filePath)
+	hints, err := client.InlayHint(ctx, protocol.InlayHintParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: uri,
+		},
+		Range: protocol.Range{
+			Start: protocol.Position{Line: uint32(startLine - 1)},
+			End: protocol.Position{
+				Line:      uint32(endLine - 1),
+				Character: uint32(len(utf16.Encode([]rune(lines[endLine-1])))),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get inlay hints: %v", err)
+	}
+
+	// Servers may return hints outside the range, which aren't shown
+	hints = slices.DeleteFunc(hints, func(hint protocol.InlayHint) bool {
+		line := int(hint.Position.Line) + 1
+		return line < startLine || line > endLine
+	})
+
+	if len(hints) == 0 {
+		return fmt.Sprintf("No inlay hints found in %s L%d-L%d", filePath, startLine, endLine), nil
+	}
+
+	// Label part locations, e.g. where an inferred type is defined, may only be
+	// filled in on resolve
+	capabilities := client.ServerCapabilities()
+	if supportsInlayHintResolve(capabilities) {
+		for i, hint := range hints {
+			if hint.Data == nil {
+				continue
+			}
+			resolved, err := client.Resolve(ctx, hint)
+			if err != nil {
+				toolsLogger.Debug("Failed to resolve inlay hint: %v", err)
+				continue
+			}
+			hints[i] = resolved
+		}
+	}
+
+	// Group hints by line, in order of position
+	sort.SliceStable(hints, func(i, j int) bool {
+		if hints[i].Position.Line != hints[j].Position.Line {
+			return hints[i].Position.Line < hints[j].Position.Line
+		}
+		return hints[i].Position.Character < hints[j].Position.Character
+	})
+	hintsByLine := make(map[int][]protocol.InlayHint)
+	for _, hint := range hints {
+		hintsByLine[int(hint.Position.Line)] = append(hintsByLine[int(hint.Position.Line)], hint)
+	}
+
+	var annotated []string
+	for i := startLine - 1; i < endLine; i++ {
+		annotated = append(annotated, insertInlayHints(lines[i], hintsByLine[i]))
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("%s L%d-L%d with %d inlay hints shown between %s and %s:\n\n",
+		filePath, startLine, endLine, len(hints), inlayHintStart, inlayHintEnd))
+	output.WriteString(addLineNumbers(strings.Join(annotated, "\n"), startLine))
+
+	// List where the types in hints are defined, when the server says
+	var definitions []string
+	for _, hint := range hints {
+		for _, part := range hint.Label {
+			if part.Location == nil {
+				continue
+			}
+			definitions = append(definitions, fmt.Sprintf("%s: %s L%d:C%d",
+				strings.TrimSpace(part.Value),
+				strings.TrimPrefix(string(part.Location.URI), "file://"),
+				part.Location.Range.Start.Line+1,
+				part.Location.Range.Start.Character+1))
+		}
+	}
+	if len(definitions) > 0 {
+		sort.Strings(definitions)
+		output.WriteString("\nDefinitions referenced by hints:\n")
+		for i, definition := range definitions {
+			if i > 0 && definition == definitions[i-1] {
+				continue
+			}
+			output.WriteString("  " + definition + "\n")
+		}
+	}
+
+	return output.String(), nil
+}
+
+// insertInlayHints inserts hints, sorted by position, into a line of source
+func insertInlayHints(line string, hints []protocol.InlayHint) string {
+	var result strings.Builder
+	last := 0
+	for _, hint := range hints {
+		offset := max(utf16OffsetToByte(line, hint.Position.Character), last)
+		result.WriteString(line[last:offset])
+		last = offset
+
+		var label strings.Builder
+		for _, part := range hint.Label {
+			label.WriteString(part.Value)
+		}
+
+		if hint.PaddingLeft {
+			result.WriteString(" ")
+		}
+		result.WriteString(inlayHintStart + label.String() + inlayHintEnd)
+		if hint.PaddingRight {
+			result.WriteString(" ")
+		}
+	}
+	result.WriteString(line[last:])
+	return result.String()
+}
+
+func supportsInlayHintResolve(capabilities protocol.ServerCapabilities) bool {
+	if capabilities.InlayHintProvider == nil {
+		return false
+	}
+	options, ok := capabilities.InlayHintProvider.(map[string]any)
+	if !ok {
+		return false
+	}
+	resolve, _ := options["resolveProvider"].(bool)
+	return resolve
+}
@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertInlayHints(t *testing.T) {
+	// One hint with a plain string label, as sent by e.g. typescript-language-server,
+	// and one with label parts
+	response := `[
+		{"position": {"line": 0, "character": 14}, "label": "a:", "kind": 2, "paddingRight": true},
+		{"position": {"line": 0, "character": 5}, "label": [{"value": ": "}, {"value": "string"}], "kind": 1}
+	]`
+
+	var hints []protocol.InlayHint
+	require.NoError(t, json.Unmarshal([]byte(response), &hints))
+	require.Len(t, hints, 2)
+	assert.Equal(t, []protocol.InlayHintLabelPart{{Value: "a:"}}, hints[0].Label)
+
+	line := "let x = greet(name);"
+	hints[0], hints[1] = hints[1], hints[0]
+	assert.Equal(t, "let x«: string» = greet(«a:» name);", insertInlayHints(line, hints))
+}
+
+func TestInsertInlayHintsUTF16(t *testing.T) {
+	hints := []protocol.InlayHint{
+		{Position: protocol.Position{Character: 6}, Label: []protocol.InlayHintLabelPart{{Value: "int"}}, PaddingLeft: true},
+	}
+	// The emoji takes two UTF-16 code units
+	assert.Equal(t, "😀x =  «int»1", insertInlayHints("😀x = 1", hints))
+}
@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// maxPreviewLength bounds the length of the source line shown for a match
+const maxPreviewLength = 120
+
+// symbolLocation is a symbol found by LocateSymbol, positioned at its name
+type symbolLocation struct {
+	Name      string
+	Kind      protocol.SymbolKind
+	Container string
+	URI       protocol.DocumentUri
+	Position  protocol.Position
+}
+
+// LocateSymbol finds the declarations of a symbol by name and returns the
+// exact position of each, to be used with position based tools like hover or
+// rename_symbol. Names can be qualified, e.g. "Type.Method", "mod::fn" or
+// "Class::method". If filePath is set only that file's symbols are searched,
+// which also finds symbols that aren't in the workspace index, like fields.
+func LocateSymbol(ctx context.Context, client *lsp.Client, symbolName, filePath string) (string, error) {
+	if strings.TrimSpace(symbolName) == "" {
+		return "", fmt.Errorf("symbolName is required")
+	}
+
+	var locations []symbolLocation
+	var err error
+	if filePath != "" {
+		locations, err = locateInDocument(ctx, client, symbolName, filePath)
+	} else {
+		locations, err = locateInWorkspace(ctx, client, symbolName)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if len(locations) == 0 {
+		if filePath != "" {
+			return fmt.Sprintf("%s not found in %s", symbolName, filePath), nil
+		}
+		return fmt.Sprintf("%s not found", symbolName), nil
+	}
+
+	// ensure output is deterministic for tests
+	slices.SortStableFunc(locations, func(a, b symbolLocation) int {
+		if c := strings.Compare(string(a.URI), string(b.URI)); c != 0 {
+			return c
+		}
+		if a.Position.Line != b.Position.Line {
+			return int(a.Position.Line) - int(b.Position.Line)
+		}
+		return int(a.Position.Character) - int(b.Position.Character)
+	})
+	locations = slices.CompactFunc(locations, func(a, b symbolLocation) bool {
+		return a.URI == b.URI && a.Position == b.Position
+	})
+
+	var output strings.Builder
+	if len(locations) == 1 {
+		fmt.Fprintf(&output, "Found 1 match for %s\n", symbolName)
+	} else {
+		fmt.Fprintf(&output, "Found %d matches for %s\n", len(locations), symbolName)
+	}
+	// Files are read once for the previews, however many matches they have
+	fileLines := make(map[string][]string)
+	for _, loc := range locations {
+		output.WriteString("\n---\n\n")
+		fmt.Fprintf(&output, "Symbol: %s\n", loc.Name)
+		fmt.Fprintf(&output, "Kind: %s\n", protocol.TableKindMap[loc.Kind])
+		if loc.Container != "" {
+			fmt.Fprintf(&output, "Container: %s\n", loc.Container)
+		}
+		fmt.Fprintf(&output, "File: %s\n", strings.TrimPrefix(string(loc.URI), "file://"))
+		fmt.Fprintf(&output, "Line: %d\n", loc.Position.Line+1)
+		fmt.Fprintf(&output, "Column: %d\n", loc.Position.Character+1)
+		path := loc.URI.Path()
+		lines, ok := fileLines[path]
+		if !ok {
+			if content, err := os.ReadFile(path); err == nil {
+				lines = strings.Split(string(content), "\n")
+			}
+			fileLines[path] = lines
+		}
+		if preview := linePreview(lines, loc.Position.Line); preview != "" {
+			fmt.Fprintf(&output, "Preview: %s\n", preview)
+		}
+	}
+
+	return output.String(), nil
+}
+
+// locateInWorkspace finds the symbols matching symbolName with
+// workspace/symbol, using the name's selection range in the symbol's file
+func locateInWorkspace(ctx context.Context, client *lsp.Client, symbolName string) ([]symbolLocation, error) {
+	queryName, results, err := QuerySymbol(ctx, client, symbolName)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := filterLocateCandidates(results, queryName)
+
+	// Not every server understands qualified queries, so fall back to the
+	// unqualified name and let the container narrow down the results
+	if len(matches) == 0 {
+		if name := unqualifiedName(queryName); name != queryName {
+			_, results, err = QuerySymbol(ctx, client, name)
+			if err != nil {
+				return nil, err
+			}
+			matches = filterLocateCandidates(results, queryName)
+		}
+	}
+
+	var locations []symbolLocation
+	for _, symbol := range matches {
+		loc := symbol.GetLocation()
+		err := client.OpenFile(ctx, loc.URI.Path())
+		if err != nil {
+			toolsLogger.Error("Error opening file: %v", err)
+			continue
+		}
+
+		locations = append(locations, symbolLocation{
+			Name:      symbol.GetName(),
+			Kind:      symbol.GetKind(),
+			Container: symbol.GetContainerName(),
+			URI:       loc.URI,
+			Position:  declarationNamePosition(ctx, client, symbol),
+		})
+	}
+	return locations, nil
+}
+
+// filterLocateCandidates keeps the workspace symbols matching symbolName by
+// their qualified name. Symbols without a container can't be checked against
+// a qualifier, so they are matched the way ReadDefinition matches them.
+func filterLocateCandidates(results []protocol.WorkspaceSymbolResult, symbolName string) []protocol.WorkspaceSymbolResult {
+	var matches []protocol.WorkspaceSymbolResult
+	for _, symbol := range results {
+		container := symbol.GetContainerName()
+		if container == "" && matchesSymbolName(symbol, symbolName) ||
+			container != "" && matchesQualifiedName(container+"."+symbol.GetName(), symbolName) ||
+			matchesQualifiedName(symbol.GetName(), symbolName) {
+			matches = append(matches, symbol)
+		}
+	}
+	return matches
+}
+
+// locateInDocument finds the symbols matching symbolName among the document
+// symbols of a file, qualifying each with the names of its parents
+func locateInDocument(ctx context.Context, client *lsp.Client, symbolName, filePath string) ([]symbolLocation, error) {
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %v", err)
+	}
+
+	uri := protocol.DocumentUri("file://" + filePath)
+	symResult, err := client.DocumentSymbol(ctx, protocol.DocumentSymbolParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document symbols: %w", err)
+	}
+
+	symbols, err := symResult.Results()
+	if err != nil {
+		return nil, fmt.Errorf("failed to process document symbols: %w", err)
+	}
+
+	var locations []symbolLocation
+	var search func(symbols []protocol.DocumentSymbolResult, parents []string)
+	search = func(symbols []protocol.DocumentSymbolResult, parents []string) {
+		for _, sym := range symbols {
+			switch s := sym.(type) {
+			case *protocol.DocumentSymbol:
+				container := strings.Join(parents, ".")
+				qualifiedName := s.Name
+				if container != "" {
+					qualifiedName = container + "." + s.Name
+				}
+				if matchesQualifiedName(qualifiedName, symbolName) {
+					locations = append(locations, symbolLocation{
+						Name:      s.Name,
+						Kind:      s.Kind,
+						Container: container,
+						URI:       uri,
+						Position:  s.SelectionRange.Start,
+					})
+				}
+
+				children := make([]protocol.DocumentSymbolResult, len(s.Children))
+				for i := range s.Children {
+					children[i] = &s.Children[i]
+				}
+				search(children, append(parents[:len(parents):len(parents)], s.Name))
+
+			case *protocol.SymbolInformation:
+				// Flat results only have a container name and the symbol's range
+				qualifiedName := s.Name
+				if s.ContainerName != "" {
+					qualifiedName = s.ContainerName + "." + s.Name
+				}
+				if matchesQualifiedName(qualifiedName, symbolName) {
+					locations = append(locations, symbolLocation{
+						Name:      s.Name,
+						Kind:      s.Kind,
+						Container: s.ContainerName,
+						URI:       uri,
+						Position:  declarationNamePosition(ctx, client, s),
+					})
+				}
+			}
+		}
+	}
+	search(symbols, nil)
+
+	return locations, nil
+}
+
+// matchesQualifiedName reports whether a symbol's qualified name, e.g.
+// "pkg.Type.Method", is named by query, e.g. "Method", "Type.Method" or
+// "Type::Method". Qualifiers may be left off from the start, but every part
+// that is given has to match.
+func matchesQualifiedName(qualifiedName, query string) bool {
+	name := normalizeQualifiedName(qualifiedName)
+	query = normalizeQualifiedName(query)
+	return name == query || strings.HasSuffix(name, "."+query)
+}
+
+// normalizeQualifiedName uses "." to separate all parts of a qualified name
+// and removes the receiver syntax of Go methods, so "(*Type).Method" becomes
+// "Type.Method"
+func normalizeQualifiedName(name string) string {
+	name = strings.ReplaceAll(strings.TrimSpace(name), "::", ".")
+
+	var parts []string
+	for _, part := range strings.Split(name, ".") {
+		if strings.HasPrefix(part, "(") && strings.HasSuffix(part, ")") {
+			part = strings.TrimPrefix(strings.Trim(part, "()"), "*")
+			// Drop type parameters, e.g. "(*List[T])"
+			part, _, _ = strings.Cut(part, "[")
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// unqualifiedName returns the last part of a qualified name
+func unqualifiedName(name string) string {
+	parts := strings.Split(normalizeQualifiedName(name), ".")
+	return parts[len(parts)-1]
+}
+
+// linePreview returns a line of a file without surrounding whitespace,
+// shortened to maxPreviewLength characters
+func linePreview(lines []string, line uint32) string {
+	if int(line) >= len(lines) {
+		return ""
+	}
+
+	preview := []rune(strings.TrimSpace(lines[line]))
+	if len(preview) > maxPreviewLength {
+		return string(preview[:maxPreviewLength]) + "..."
+	}
+	return string(preview)
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesQualifiedName(t *testing.T) {
+	tests := []struct {
+		name          string
+		qualifiedName string
+		query         string
+		want          bool
+	}{
+		{"exact", "main", "main", true},
+		{"unqualified query", "Server.Start", "Start", true},
+		{"qualified query", "Server.Start", "Server.Start", true},
+		{"wrong container", "Client.Start", "Server.Start", false},
+		{"partial name", "Server.StartAll", "Start", false},
+		{"partial container", "MyServer.Start", "Server.Start", false},
+		{"leading qualifiers left off", "example.com/project.Server.Start", "Server.Start", true},
+		{"rust path", "utils.helpers.format", "helpers::format", true},
+		{"c++ method", "ns::Class::method", "Class::method", true},
+		{"go pointer receiver", "(*SharedStruct).Method", "SharedStruct.Method", true},
+		{"go value receiver", "(SharedStruct).Method", "SharedStruct::Method", true},
+		{"go generic receiver", "(*List[T]).Push", "List.Push", true},
+		{"query longer than name", "Start", "Server.Start", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesQualifiedName(tt.qualifiedName, tt.query))
+		})
+	}
+}
+
+func TestFilterLocateCandidates(t *testing.T) {
+	symbol := func(name string, kind protocol.SymbolKind, container string) protocol.WorkspaceSymbolResult {
+		return &protocol.SymbolInformation{
+			Name:          name,
+			Kind:          kind,
+			ContainerName: container,
+		}
+	}
+
+	results := []protocol.WorkspaceSymbolResult{
+		symbol("Start", protocol.Method, "Server"),
+		symbol("Start", protocol.Method, "Client"),
+		// Qualified by its type rather than its container, like gopls does
+		symbol("Worker.Start", protocol.Method, "example.com/project"),
+		// Fuzzy match from the server
+		symbol("StartServer", protocol.Function, "main"),
+		// Without a container the qualifier can't be checked
+		symbol("Stop", protocol.Method, ""),
+	}
+
+	names := func(candidates []protocol.WorkspaceSymbolResult) []string {
+		var result []string
+		for _, c := range candidates {
+			result = append(result, c.GetContainerName()+"."+c.GetName())
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Server.Start", "Client.Start", "example.com/project.Worker.Start"},
+		names(filterLocateCandidates(results, "Start")))
+	assert.Equal(t, []string{"Server.Start"}, names(filterLocateCandidates(results, "Server::Start")))
+	assert.Equal(t, []string{"example.com/project.Worker.Start"}, names(filterLocateCandidates(results, "Worker.Start")))
+	assert.Equal(t, []string{".Stop"}, names(filterLocateCandidates(results, "Server::Stop")))
+	assert.Empty(t, filterLocateCandidates(results, "Restart"))
+}
+
+func TestUnqualifiedName(t *testing.T) {
+	assert.Equal(t, "Method", unqualifiedName("Type.Method"))
+	assert.Equal(t, "fn", unqualifiedName("module::fn"))
+	assert.Equal(t, "main", unqualifiedName("main"))
+}
+
+func TestLinePreview(t *testing.T) {
+	lines := []string{"package main", "", "\tfunc Long() {" + strings.Repeat("x", 200), "}"}
+
+	assert.Equal(t, "package main", linePreview(lines, 0))
+	assert.Equal(t, "", linePreview(lines, 1))
+	assert.Equal(t, "func Long() {"+strings.Repeat("x", maxPreviewLength-13)+"...", linePreview(lines, 2))
+	assert.Equal(t, "", linePreview(lines, 10))
+	assert.Equal(t, "", linePreview(nil, 0))
+}
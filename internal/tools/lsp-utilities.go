@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
+	"time"
+	"unicode/utf16"
 
 	"github.com/isaacphi/mcp-language-server/internal/lsp"
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
@@ -103,46 +106,9 @@ func GetFullDefinition(ctx context.Context, client *lsp.Client, startLocation pr
 			return "", protocol.Location{}, nil, fmt.Errorf("line number out of range")
 		}
 
-		line := lines[symbolRange.End.Line]
-		trimmedLine := strings.TrimSpace(line)
-
 		// In some cases (python), constant definitions do not include the full body and instead
 		// end with an opening bracket. In this case, parse the file until the closing bracket
-		if len(trimmedLine) > 0 {
-			lastChar := trimmedLine[len(trimmedLine)-1]
-			if lastChar == '(' || lastChar == '[' || lastChar == '{' || lastChar == '<' {
-				// Find matching closing bracket
-				bracketStack := []rune{rune(lastChar)}
-				lineNum := symbolRange.End.Line + 1
-
-				for lineNum < uint32(len(lines)) {
-					line := lines[lineNum]
-					for pos, char := range line {
-						if char == '(' || char == '[' || char == '{' || char == '<' {
-							bracketStack = append(bracketStack, char)
-						} else if char == ')' || char == ']' || char == '}' || char == '>' {
-							if len(bracketStack) > 0 {
-								lastOpen := bracketStack[len(bracketStack)-1]
-								if (lastOpen == '(' && char == ')') ||
-									(lastOpen == '[' && char == ']') ||
-									(lastOpen == '{' && char == '}') ||
-									(lastOpen == '<' && char == '>') {
-									bracketStack = bracketStack[:len(bracketStack)-1]
-									if len(bracketStack) == 0 {
-										// Found matching bracket - update range
-										symbolRange.End.Line = lineNum
-										symbolRange.End.Character = uint32(pos + 1)
-										goto foundClosing
-									}
-								}
-							}
-						}
-					}
-					lineNum++
-				}
-			foundClosing:
-			}
-		}
+		symbolRange = extendToClosingBracket(lines, symbolRange)
 
 		// Return the text within the range
 		if int(symbolRange.End.Line) >= len(lines) {
@@ -201,3 +167,266 @@ func GetLineRangesToDisplay(ctx context.Context, client *lsp.Client, locations [
 
 	return linesToShow, nil
 }
+
+// resolveQueryLocations returns the locations to send position based requests
+// to. If symbolName is set, it is looked up in the workspace using the same
+// matching rules as ReadDefinition, keeping only the matches in filePath if it
+// is set. Otherwise the 1-indexed line and column in filePath are used.
+func resolveQueryLocations(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) ([]protocol.Location, error) {
+	return resolveLocations(ctx, client, symbolName, filePath, line, column, false)
+}
+
+// resolveQueryNameLocations is like resolveQueryLocations, but positions the
+// symbols found by name at their names. Some servers point workspace symbols
+// at the start of the declaration, where requests like hover find nothing.
+func resolveQueryNameLocations(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) ([]protocol.Location, error) {
+	return resolveLocations(ctx, client, symbolName, filePath, line, column, true)
+}
+
+func resolveLocations(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int, atName bool) ([]protocol.Location, error) {
+	if symbolName == "" {
+		if filePath == "" || line < 1 || column < 1 {
+			return nil, fmt.Errorf("either symbolName or filePath, line and column are required")
+		}
+
+		err := client.OpenFile(ctx, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not open file: %v", err)
+		}
+
+		// Convert 1-indexed line/column to 0-indexed for LSP protocol
+		position := protocol.Position{
+			Line:      uint32(line - 1),
+			Character: uint32(column - 1),
+		}
+		return []protocol.Location{{
+			URI:   protocol.DocumentUri("file://" + filePath),
+			Range: protocol.Range{Start: position, End: position},
+		}}, nil
+	}
+
+	symbolName, results, err := QuerySymbol(ctx, client, symbolName)
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []protocol.Location
+	for _, symbol := range results {
+		if !matchesSymbolName(symbol, symbolName) {
+			continue
+		}
+
+		loc := symbol.GetLocation()
+		if filePath != "" && filepath.Clean(loc.URI.Path()) != filepath.Clean(filePath) {
+			continue
+		}
+
+		err := client.OpenFile(ctx, loc.URI.Path())
+		if err != nil {
+			toolsLogger.Error("Error opening file: %v", err)
+			continue
+		}
+
+		if !atName {
+			locations = append(locations, loc)
+			continue
+		}
+
+		position := declarationNamePosition(ctx, client, symbol)
+		locations = append(locations, protocol.Location{
+			URI:   loc.URI,
+			Range: protocol.Range{Start: position, End: position},
+		})
+	}
+
+	if len(locations) == 0 {
+		if filePath != "" {
+			return nil, fmt.Errorf("%s not found in %s", symbolName, filePath)
+		}
+		return nil, fmt.Errorf("%s not found", symbolName)
+	}
+
+	return locations, nil
+}
+
+// extendToClosingBracket extends a range whose last line ends with an opening
+// bracket to the matching closing bracket
+func extendToClosingBracket(lines []string, symbolRange protocol.Range) protocol.Range {
+	line := lines[symbolRange.End.Line]
+	trimmedLine := strings.TrimSpace(line)
+	if len(trimmedLine) == 0 {
+		return symbolRange
+	}
+
+	lastChar := trimmedLine[len(trimmedLine)-1]
+	if lastChar != '(' && lastChar != '[' && lastChar != '{' && lastChar != '<' {
+		return symbolRange
+	}
+
+	// Find matching closing bracket
+	bracketStack := []rune{rune(lastChar)}
+	for lineNum := symbolRange.End.Line + 1; lineNum < uint32(len(lines)); lineNum++ {
+		for pos, char := range lines[lineNum] {
+			if char == '(' || char == '[' || char == '{' || char == '<' {
+				bracketStack = append(bracketStack, char)
+			} else if char == ')' || char == ']' || char == '}' || char == '>' {
+				if len(bracketStack) > 0 {
+					lastOpen := bracketStack[len(bracketStack)-1]
+					if (lastOpen == '(' && char == ')') ||
+						(lastOpen == '[' && char == ']') ||
+						(lastOpen == '{' && char == '}') ||
+						(lastOpen == '<' && char == '>') {
+						bracketStack = bracketStack[:len(bracketStack)-1]
+						if len(bracketStack) == 0 {
+							// Found matching bracket - update range
+							symbolRange.End.Line = lineNum
+							symbolRange.End.Character = uint32(pos + 1)
+							return symbolRange
+						}
+					}
+				}
+			}
+		}
+	}
+	return symbolRange
+}
+
+// describeQueryTarget names what resolveQueryLocations looked up, for messages:
+// the symbol name, or the file and 1-indexed position
+func describeQueryTarget(symbolName, filePath string, line, column int) string {
+	if symbolName != "" {
+		return symbolName
+	}
+	return fmt.Sprintf("%s L%d:C%d", filePath, line, column)
+}
+
+// formatQueryLocation formats the file and 1-indexed start position of a location
+func formatQueryLocation(loc protocol.Location) string {
+	return fmt.Sprintf("%s L%d:C%d", strings.TrimPrefix(string(loc.URI), "file://"), loc.Range.Start.Line+1, loc.Range.Start.Character+1)
+}
+
+// formatDefinitionAt returns the full definition surrounding a location with a
+// header and line numbers, in the same format as ReadDefinition
+func formatDefinitionAt(ctx context.Context, client *lsp.Client, loc protocol.Location) (string, error) {
+	err := client.OpenFile(ctx, loc.URI.Path())
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	definition, defLoc, symbol, err := GetFullDefinition(ctx, client, loc)
+	if err != nil {
+		return "", err
+	}
+
+	// Declarations inside a function body, like local types, are not document
+	// symbols themselves, so the smallest symbol around them is the function
+	if ds, ok := symbol.(*protocol.DocumentSymbol); ok &&
+		loc.Range.Start.Line > ds.Range.Start.Line && !containsPosition(ds.SelectionRange, loc.Range.Start) {
+		return formatLocalDefinitionAt(loc, ds)
+	}
+
+	locationInfo := fmt.Sprintf(
+		"Symbol: %s\n"+
+			"File: %s\n"+
+			"Kind: %s\n"+
+			"Range: L%d:C%d - L%d:C%d\n\n",
+		symbol.GetName(),
+		strings.TrimPrefix(string(defLoc.URI), "file://"),
+		protocol.TableKindMap[symbol.GetKind()],
+		defLoc.Range.Start.Line+1,
+		defLoc.Range.Start.Character+1,
+		defLoc.Range.End.Line+1,
+		defLoc.Range.End.Character+1,
+	)
+
+	return locationInfo + addLineNumbers(definition, int(defLoc.Range.Start.Line)+1), nil
+}
+
+// formatLocalDefinitionAt returns the declaration at a location inside the
+// body of symbol, from its line to the matching closing bracket if it opens one
+func formatLocalDefinitionAt(loc protocol.Location, symbol *protocol.DocumentSymbol) (string, error) {
+	content, err := os.ReadFile(loc.URI.Path())
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if int(loc.Range.Start.Line) >= len(lines) {
+		return "", fmt.Errorf("line number out of range")
+	}
+
+	startLine := strings.TrimSuffix(lines[loc.Range.Start.Line], "\r")
+	declRange := extendToClosingBracket(lines, protocol.Range{
+		Start: protocol.Position{Line: loc.Range.Start.Line},
+		End:   protocol.Position{Line: loc.Range.Start.Line, Character: uint32(len(utf16.Encode([]rune(startLine))))},
+	})
+
+	// The location usually covers the declared name
+	name := symbol.Name
+	if loc.Range.Start.Line == loc.Range.End.Line {
+		runes := utf16.Encode([]rune(startLine))
+		if start, end := int(loc.Range.Start.Character), int(loc.Range.End.Character); start < end && end <= len(runes) {
+			name = string(utf16.Decode(runes[start:end]))
+		}
+	}
+
+	locationInfo := fmt.Sprintf(
+		"Symbol: %s\n"+
+			"File: %s\n"+
+			"Container: %s\n"+
+			"Range: L%d:C%d - L%d:C%d\n\n",
+		name,
+		strings.TrimPrefix(string(loc.URI), "file://"),
+		symbol.Name,
+		declRange.Start.Line+1,
+		declRange.Start.Character+1,
+		declRange.End.Line+1,
+		declRange.End.Character+1,
+	)
+
+	definition := strings.Join(lines[declRange.Start.Line:declRange.End.Line+1], "\n")
+	return locationInfo + addLineNumbers(definition, int(declRange.Start.Line)+1), nil
+}
+
+// sortLocations sorts locations by file and position and removes duplicates
+func sortLocations(locations []protocol.Location) []protocol.Location {
+	slices.SortFunc(locations, func(a, b protocol.Location) int {
+		if c := strings.Compare(string(a.URI), string(b.URI)); c != 0 {
+			return c
+		}
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return int(a.Range.Start.Line) - int(b.Range.Start.Line)
+		}
+		return int(a.Range.Start.Character) - int(b.Range.Start.Character)
+	})
+	return slices.Compact(locations)
+}
+
+// fileReadyTimeout bounds how long openFileAndWait waits for the server
+const fileReadyTimeout = 5 * time.Second
+
+// openFileAndWait opens a file and, if it wasn't open yet, waits until the
+// server has processed it. Servers publish diagnostics for a document once
+// they have, which is used as the signal. Servers that don't publish any are
+// given up to fileReadyTimeout.
+func openFileAndWait(ctx context.Context, client *lsp.Client, filePath string) error {
+	if client.IsFileOpen(filePath) {
+		return nil
+	}
+
+	published, cancel := client.DiagnosticsPublished(protocol.DocumentUri("file://" + filePath))
+	defer cancel()
+
+	if err := client.OpenFile(ctx, filePath); err != nil {
+		return fmt.Errorf("could not open file: %v", err)
+	}
+
+	select {
+	case <-published:
+	case <-time.After(fileReadyTimeout):
+		toolsLogger.Debug("No diagnostics published for %s within %s, continuing", filePath, fileReadyTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
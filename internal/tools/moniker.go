@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// GetMonikers shows the monikers of a symbol, identified either by symbolName
+// or by a 1-indexed position in filePath. A moniker is a stable identifier for
+// a symbol that can be used to find it in other projects or code indexes.
+func GetMonikers(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) (string, error) {
+	if !supportsMonikers(client.ServerCapabilities()) {
+		return "", fmt.Errorf("the language server does not support monikers")
+	}
+
+	queryLocations, err := resolveQueryLocations(ctx, client, symbolName, filePath, line, column)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	for _, loc := range queryLocations {
+		fmt.Fprintf(&output, "---\n\n%s L%d:C%d\n",
+			strings.TrimPrefix(string(loc.URI), "file://"), loc.Range.Start.Line+1, loc.Range.Start.Character+1)
+
+		monikers, err := monikersAt(ctx, client, loc)
+		if err != nil {
+			fmt.Fprintf(&output, "Error: %v\n\n", err)
+			continue
+		}
+		if len(monikers) == 0 {
+			output.WriteString("No monikers found\n\n")
+			continue
+		}
+
+		for _, moniker := range monikers {
+			fmt.Fprintf(&output, "Scheme: %s\n", moniker.Scheme)
+			fmt.Fprintf(&output, "Identifier: %s\n", moniker.Identifier)
+			fmt.Fprintf(&output, "Unique: %s\n", moniker.Unique)
+			if moniker.Kind != nil {
+				fmt.Fprintf(&output, "Kind: %s\n", *moniker.Kind)
+			}
+			output.WriteString("\n")
+		}
+	}
+
+	return output.String(), nil
+}
+
+// monikersAt requests the monikers of the symbol at a location
+func monikersAt(ctx context.Context, client *lsp.Client, loc protocol.Location) ([]protocol.Moniker, error) {
+	return client.Moniker(ctx, protocol.MonikerParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: loc.URI},
+			Position:     loc.Range.Start,
+		},
+	})
+}
+
+// monikerSummary returns a "Monikers: ..." line for the symbol at a location,
+// for tools that include monikers in their output. It is empty if the server
+// doesn't support monikers or has none for the symbol.
+func monikerSummary(ctx context.Context, client *lsp.Client, loc protocol.Location) string {
+	if !supportsMonikers(client.ServerCapabilities()) {
+		return ""
+	}
+
+	monikers, err := monikersAt(ctx, client, loc)
+	if err != nil {
+		toolsLogger.Debug("Failed to get monikers: %v", err)
+		return ""
+	}
+	if len(monikers) == 0 {
+		return ""
+	}
+
+	return "Monikers: " + formatMonikers(monikers) + "\n"
+}
+
+// formatMonikers formats monikers on one line, like
+// "tsc:lib/index:Client.send (export, unique: project)"
+func formatMonikers(monikers []protocol.Moniker) string {
+	parts := make([]string, 0, len(monikers))
+	for _, moniker := range monikers {
+		details := "unique: " + string(moniker.Unique)
+		if moniker.Kind != nil {
+			details = string(*moniker.Kind) + ", " + details
+		}
+		parts = append(parts, fmt.Sprintf("%s:%s (%s)", moniker.Scheme, moniker.Identifier, details))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func supportsMonikers(capabilities protocol.ServerCapabilities) bool {
+	if capabilities.MonikerProvider == nil {
+		return false
+	}
+	if supported, ok := capabilities.MonikerProvider.Value.(bool); ok {
+		return supported
+	}
+	return capabilities.MonikerProvider.Value != nil
+}
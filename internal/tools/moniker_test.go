@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMonikers(t *testing.T) {
+	export := protocol.Export
+	assert.Equal(t, "tsc:lib/index:Client.send (export, unique: project), npm:client::lib/index:Client.send (unique: scheme)",
+		formatMonikers([]protocol.Moniker{
+			{Scheme: "tsc", Identifier: "lib/index:Client.send", Unique: protocol.Project, Kind: &export},
+			{Scheme: "npm", Identifier: "client::lib/index:Client.send", Unique: protocol.Scheme},
+		}))
+}
+
+func TestSupportsMonikers(t *testing.T) {
+	assert.False(t, supportsMonikers(protocol.ServerCapabilities{}))
+	assert.False(t, supportsMonikers(protocol.ServerCapabilities{
+		MonikerProvider: &protocol.Or_ServerCapabilities_monikerProvider{Value: false},
+	}))
+	assert.True(t, supportsMonikers(protocol.ServerCapabilities{
+		MonikerProvider: &protocol.Or_ServerCapabilities_monikerProvider{Value: true},
+	}))
+	assert.True(t, supportsMonikers(protocol.ServerCapabilities{
+		MonikerProvider: &protocol.Or_ServerCapabilities_monikerProvider{Value: protocol.MonikerOptions{}},
+	}))
+}
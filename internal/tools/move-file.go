@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+)
+
+// MoveFile moves or renames a file or directory. Before moving it, the language
+// server is asked for the edits that keep other files working, such as updated
+// imports, and those are applied.
+func MoveFile(ctx context.Context, client *lsp.Client, oldPath, newPath string) (string, error) {
+	oldPath, err := filepath.Abs(oldPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %v", err)
+	}
+	newPath, err = filepath.Abs(newPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %v", err)
+	}
+
+	info, err := os.Stat(oldPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to access %s: %w", oldPath, err)
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return "", fmt.Errorf("destination already exists: %s", newPath)
+	}
+	if strings.HasPrefix(newPath, oldPath+"/") {
+		return "", fmt.Errorf("cannot move a directory into itself: %s", newPath)
+	}
+
+	params := protocol.RenameFilesParams{
+		Files: []protocol.FileRename{{
+			OldURI: "file://" + oldPath,
+			NewURI: "file://" + newPath,
+		}},
+	}
+
+	// Create the destination before changing anything, so that a bad
+	// destination doesn't leave other files edited for a move that fails
+	removeCreatedDirs, err := makeParentDirs(newPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	fileOperations := serverFileOperations(client.ServerCapabilities())
+
+	// The edits refer to files as they are before the move, so they are applied
+	// first. The edited files are restored if the edits or the move fail.
+	var output strings.Builder
+	var edited map[string][]byte
+	if matchesFileOperationFilters(fileOperations.WillRename, oldPath, info.IsDir()) {
+		edit, err := client.WillRenameFiles(ctx, params)
+		if err != nil {
+			removeCreatedDirs()
+			return "", fmt.Errorf("failed to get edits for the move: %v", err)
+		}
+		edited, err = readEditedFiles(edit)
+		if err != nil {
+			removeCreatedDirs()
+			return "", fmt.Errorf("failed to apply edits for the move: %v", err)
+		}
+		if err := utilities.ApplyWorkspaceEdit(edit); err != nil {
+			restoreFiles(edited)
+			removeCreatedDirs()
+			return "", fmt.Errorf("failed to apply edits for the move: %v", err)
+		}
+		if len(edit.Changes) > 0 || len(edit.DocumentChanges) > 0 {
+			output.WriteString("Updated references to the moved files:\n")
+			output.WriteString(describeWorkspaceEdit(edit))
+		} else {
+			output.WriteString("No other files needed changes\n")
+		}
+	} else {
+		output.WriteString("The language server does not update references when files are moved, other files may need changes\n")
+	}
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		restoreFiles(edited)
+		removeCreatedDirs()
+		return "", fmt.Errorf("failed to move %s: %w", oldPath, err)
+	}
+
+	if err := client.RenameOpenFiles(ctx, oldPath, newPath); err != nil {
+		toolsLogger.Error("Failed to update open files after move: %v", err)
+	}
+
+	if matchesFileOperationFilters(fileOperations.DidRename, oldPath, info.IsDir()) {
+		if err := client.DidRenameFiles(ctx, params); err != nil {
+			toolsLogger.Error("Failed to notify server of move: %v", err)
+		}
+	}
+
+	return fmt.Sprintf("Moved %s to %s\n\n%s", oldPath, newPath, output.String()), nil
+}
+
+// makeParentDirs creates the missing parent directories of path. It returns a
+// function that removes the directories it created again, if they are empty.
+func makeParentDirs(path string) (func(), error) {
+	// Missing directories, deepest first
+	var missing []string
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		missing = append(missing, dir)
+	}
+
+	removeCreated := func() {
+		for _, dir := range missing {
+			if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+				toolsLogger.Debug("Not removing %s: %v", dir, err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		removeCreated()
+		return nil, err
+	}
+	return removeCreated, nil
+}
+
+// readEditedFiles reads the current content of every file a workspace edit
+// changes the text of, so that it can be restored
+func readEditedFiles(edit protocol.WorkspaceEdit) (map[string][]byte, error) {
+	var paths []string
+	for uri := range edit.Changes {
+		paths = append(paths, strings.TrimPrefix(string(uri), "file://"))
+	}
+	for _, change := range edit.DocumentChanges {
+		if change.TextDocumentEdit != nil {
+			paths = append(paths, strings.TrimPrefix(string(change.TextDocumentEdit.TextDocument.URI), "file://"))
+		}
+	}
+
+	contents := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		if _, ok := contents[path]; ok {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		contents[path] = content
+	}
+	return contents, nil
+}
+
+// restoreFiles writes back the contents read by readEditedFiles
+func restoreFiles(contents map[string][]byte) {
+	for path, content := range contents {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			toolsLogger.Error("Failed to restore %s: %v", path, err)
+		}
+	}
+}
+
+// serverFileOperations returns the file operations the server wants to be told
+// about. It is never nil.
+func serverFileOperations(capabilities protocol.ServerCapabilities) *protocol.FileOperationOptions {
+	if capabilities.Workspace == nil || capabilities.Workspace.FileOperations == nil {
+		return &protocol.FileOperationOptions{}
+	}
+	return capabilities.Workspace.FileOperations
+}
+
+// matchesFileOperationFilters reports whether a server registered for a file
+// operation on path, using the filters in its registration options
+func matchesFileOperationFilters(options *protocol.FileOperationRegistrationOptions, path string, isDir bool) bool {
+	if options == nil {
+		return false
+	}
+
+	for _, filter := range options.Filters {
+		if filter.Scheme != "" && filter.Scheme != "file" {
+			continue
+		}
+
+		pattern := filter.Pattern
+		if pattern.Matches != nil {
+			if *pattern.Matches == protocol.FilePattern && isDir {
+				continue
+			}
+			if *pattern.Matches == protocol.FolderPattern && !isDir {
+				continue
+			}
+		}
+
+		glob, target := pattern.Glob, filepath.ToSlash(path)
+		if pattern.Options != nil && pattern.Options.IgnoreCase {
+			glob, target = strings.ToLower(glob), strings.ToLower(target)
+		}
+		if matched, _ := doublestar.Match(glob, target); matched {
+			return true
+		}
+	}
+
+	return false
+}
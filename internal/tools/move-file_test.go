@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesFileOperationFilters(t *testing.T) {
+	files := protocol.FilePattern
+	folders := protocol.FolderPattern
+	options := &protocol.FileOperationRegistrationOptions{
+		Filters: []protocol.FileOperationFilter{
+			{Scheme: "file", Pattern: protocol.FileOperationPattern{Glob: "**/*.{ts,tsx}", Matches: &files}},
+			{Scheme: "file", Pattern: protocol.FileOperationPattern{Glob: "**/src/**", Matches: &folders}},
+			{Scheme: "untitled", Pattern: protocol.FileOperationPattern{Glob: "**/*.go"}},
+			{Pattern: protocol.FileOperationPattern{Glob: "**/*.RS", Options: &protocol.FileOperationPatternOptions{IgnoreCase: true}}},
+		},
+	}
+
+	assert.True(t, matchesFileOperationFilters(options, "/project/app.tsx", false))
+	assert.False(t, matchesFileOperationFilters(options, "/project/app.tsx", true))
+	assert.True(t, matchesFileOperationFilters(options, "/project/src/components", true))
+	assert.False(t, matchesFileOperationFilters(options, "/project/src/components", false))
+	assert.False(t, matchesFileOperationFilters(options, "/project/main.go", false))
+	assert.True(t, matchesFileOperationFilters(options, "/project/lib.rs", false))
+	assert.False(t, matchesFileOperationFilters(nil, "/project/app.tsx", false))
+}
+
+func TestRestoreEditedFiles(t *testing.T) {
+	dir := t.TempDir()
+	aPath := filepath.Join(dir, "a.ts")
+	bPath := filepath.Join(dir, "b.ts")
+	require.NoError(t, os.WriteFile(aPath, []byte("import './old'\n"), 0644))
+	require.NoError(t, os.WriteFile(bPath, []byte("import './old'\n"), 0644))
+
+	importRange := protocol.Range{
+		Start: protocol.Position{Line: 0, Character: 8},
+		End:   protocol.Position{Line: 0, Character: 13},
+	}
+	edit := protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentUri][]protocol.TextEdit{
+			protocol.DocumentUri("file://" + aPath): {{Range: importRange, NewText: "./new"}},
+		},
+		DocumentChanges: []protocol.DocumentChange{{TextDocumentEdit: &protocol.TextDocumentEdit{
+			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: protocol.DocumentUri("file://" + bPath)},
+			},
+			Edits: []protocol.Or_TextDocumentEdit_edits_Elem{{Value: protocol.TextEdit{Range: importRange, NewText: "./new"}}},
+		}}},
+	}
+
+	edited, err := readEditedFiles(edit)
+	require.NoError(t, err)
+	assert.Len(t, edited, 2)
+
+	require.NoError(t, utilities.ApplyWorkspaceEdit(edit))
+	content, err := os.ReadFile(bPath)
+	require.NoError(t, err)
+	assert.Equal(t, "import './new'\n", string(content))
+
+	restoreFiles(edited)
+	for _, path := range []string{aPath, bPath} {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "import './old'\n", string(content))
+	}
+}
+
+func TestMakeParentDirs(t *testing.T) {
+	root := t.TempDir()
+
+	removeCreated, err := makeParentDirs(filepath.Join(root, "a", "b", "file.go"))
+	require.NoError(t, err)
+	assert.DirExists(t, filepath.Join(root, "a", "b"))
+
+	// Only the directories that were created are removed
+	removeCreated()
+	assert.NoDirExists(t, filepath.Join(root, "a"))
+	assert.DirExists(t, root)
+
+	// Directories that are no longer empty are kept
+	removeCreated, err = makeParentDirs(filepath.Join(root, "a", "b", "file.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a", "other.go"), []byte("package a\n"), 0644))
+	removeCreated()
+	assert.NoDirExists(t, filepath.Join(root, "a", "b"))
+	assert.FileExists(t, filepath.Join(root, "a", "other.go"))
+}
@@ -0,0 +1,201 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/isaacphi/mcp-language-server/internal/utilities"
+)
+
+// organizeImportsKinds are the source actions run by OrganizeImports, in order.
+// Missing imports are added first so that organizing sorts them too. Fix-all
+// actions are left out because they rewrite more than imports.
+var organizeImportsKinds = []protocol.CodeActionKind{
+	"source.addMissingImports",
+	protocol.SourceOrganizeImports,
+}
+
+// importLinePattern matches lines that declare imports in common languages: Go
+// import specs, JS/TS and Python imports, Java and Kotlin imports, Rust uses
+// and C/C++ includes
+var importLinePattern = regexp.MustCompile(`^\s*(import\b|from\s+\S+\s+import\b|use\s|#\s*include\b|(\w+\s+|[._]\s+)?"[^"]+"\s*$)`)
+
+// goImportDeclPattern matches single line Go import declarations, so that they
+// compare equal to the same spec inside an import block
+var goImportDeclPattern = regexp.MustCompile(`^import\s+((\w+\s+|[._]\s+)?"[^"]+")$`)
+
+// OrganizeImports runs the import related source actions the server offers
+// for a file, applies their edits and reports which imports changed
+func OrganizeImports(ctx context.Context, client *lsp.Client, filePath string) (string, error) {
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	before, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var steps strings.Builder
+	offeredKinds := serverCodeActionKinds(client.ServerCapabilities())
+	for _, kind := range organizeImportsKinds {
+		if offeredKinds != nil && !slices.ContainsFunc(offeredKinds, func(offered protocol.CodeActionKind) bool {
+			return codeActionKindContains(offered, kind) || codeActionKindContains(kind, offered)
+		}) {
+			steps.WriteString(fmt.Sprintf("%s: not supported by the server\n", kind))
+			continue
+		}
+
+		// Request actions for the current content, which earlier steps may have changed
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		actions, _, err := requestCodeActions(ctx, client, filePath, CodeActionOptions{
+			StartLine: 1,
+			EndLine:   len(strings.Split(string(content), "\n")),
+			Kinds:     []string{string(kind)},
+		})
+		if err != nil {
+			toolsLogger.Error("Failed to get %s code actions: %v", kind, err)
+			steps.WriteString(fmt.Sprintf("%s: skipped, %v\n", kind, err))
+			continue
+		}
+
+		applied := false
+		for _, item := range actions {
+			// Servers may return other kinds than requested
+			action, ok := item.Value.(protocol.CodeAction)
+			if !ok || !codeActionKindContains(kind, action.Kind) || action.Disabled != nil {
+				continue
+			}
+
+			if _, err := applyCodeAction(ctx, client, item); err != nil {
+				return "", fmt.Errorf("failed to apply %s: %v", kind, err)
+			}
+			steps.WriteString(fmt.Sprintf("%s: applied %q\n", kind, action.Title))
+			applied = true
+
+			// Let the server see the result before the next step
+			if err := client.NotifyChange(ctx, filePath); err != nil {
+				toolsLogger.Error("Failed to notify change: %v", err)
+			}
+			break
+		}
+		if !applied {
+			steps.WriteString(fmt.Sprintf("%s: no changes\n", kind))
+		}
+	}
+
+	after, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if string(before) == string(after) {
+		return fmt.Sprintf("Imports in %s are already organized\n\n%s", filePath, steps.String()), nil
+	}
+
+	added, removed := importChanges(before, after)
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Organized imports in %s\n\n", filePath))
+	output.WriteString(steps.String())
+	if len(added) > 0 {
+		output.WriteString("\nImports added:\n")
+		for _, line := range added {
+			output.WriteString("  " + line + "\n")
+		}
+	}
+	if len(removed) > 0 {
+		output.WriteString("\nImports removed:\n")
+		for _, line := range removed {
+			output.WriteString("  " + line + "\n")
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		output.WriteString("\nNo imports were added or removed, only reordered or reformatted\n")
+	}
+
+	return output.String(), nil
+}
+
+// importChanges compares the import lines of two versions of a file. Lines that
+// were only moved, e.g. when imports are sorted, are not reported.
+func importChanges(before, after []byte) (added []string, removed []string) {
+	removedLines, addedLines := utilities.LineChanges(before, after)
+
+	normalize := func(line string) string {
+		line = strings.TrimSuffix(strings.TrimSpace(line), ";")
+		return goImportDeclPattern.ReplaceAllString(line, "$1")
+	}
+	isImport := func(line string) bool {
+		// The opening line of a Go import block is not an import itself
+		return importLinePattern.MatchString(line) && normalize(line) != "import ("
+	}
+
+	remaining := make(map[string]int)
+	for _, line := range addedLines {
+		if isImport(line) {
+			remaining[normalize(line)]++
+		}
+	}
+	for _, line := range removedLines {
+		if !isImport(line) {
+			continue
+		}
+		if remaining[normalize(line)] > 0 {
+			remaining[normalize(line)]--
+			continue
+		}
+		removed = append(removed, normalize(line))
+	}
+	for _, line := range addedLines {
+		if isImport(line) && remaining[normalize(line)] > 0 {
+			remaining[normalize(line)]--
+			added = append(added, normalize(line))
+		}
+	}
+
+	return added, removed
+}
+
+// serverCodeActionKinds returns the code action kinds the server says it
+// supports, or nil if it doesn't say. It returns an empty slice if the server
+// doesn't support code actions at all.
+func serverCodeActionKinds(capabilities protocol.ServerCapabilities) []protocol.CodeActionKind {
+	switch v := capabilities.CodeActionProvider.(type) {
+	case bool:
+		if !v {
+			return []protocol.CodeActionKind{}
+		}
+	case map[string]any:
+		// The capability is untyped, so decode it through JSON
+		var options protocol.CodeActionOptions
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		if err := json.Unmarshal(data, &options); err != nil {
+			return nil
+		}
+		if len(options.CodeActionKinds) > 0 {
+			return options.CodeActionKinds
+		}
+	}
+	return nil
+}
+
+// codeActionKindContains reports whether kind is parent or one of its sub-kinds.
+// Code action kinds are hierarchical, so "source" contains "source.fixAll".
+func codeActionKindContains(parent, kind protocol.CodeActionKind) bool {
+	return kind == parent || strings.HasPrefix(string(kind), string(parent)+".")
+}
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportChanges(t *testing.T) {
+	tests := []struct {
+		name            string
+		before          string
+		after           string
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		{
+			name:            "Go import block",
+			before:          "package main\n\nimport (\n\t\"strings\"\n\t\"fmt\"\n)\n",
+			after:           "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+			expectedAdded:   []string{`"os"`},
+			expectedRemoved: []string{`"strings"`},
+		},
+		{
+			name:   "Go named import",
+			before: "package main\n\nimport \"fmt\"\n",
+			after:  "package main\n\nimport (\n\t\"fmt\"\n\tstr \"strings\"\n)\n",
+			// The single line import moving into a block is not a change
+			expectedAdded: []string{`str "strings"`},
+		},
+		{
+			name:          "Sorting only",
+			before:        "import os\nimport sys\nimport abc\n",
+			after:         "import abc\nimport os\nimport sys\n",
+			expectedAdded: nil,
+		},
+		{
+			name:            "TypeScript",
+			before:          "import { a } from './a';\nimport { b } from './b';\n\nconst x = 1;\n",
+			after:           "import { a } from './a';\n\nconst x = 1;\n",
+			expectedRemoved: []string{"import { b } from './b'"},
+		},
+		{
+			name:          "Other changes are ignored",
+			before:        "fn main() {\n}\n",
+			after:         "use std::fmt;\n\nfn main() {\n    let x = 1;\n}\n",
+			expectedAdded: []string{"use std::fmt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := importChanges([]byte(tt.before), []byte(tt.after))
+			assert.Equal(t, tt.expectedAdded, added)
+			assert.Equal(t, tt.expectedRemoved, removed)
+		})
+	}
+}
+
+func TestCodeActionKindContains(t *testing.T) {
+	assert.True(t, codeActionKindContains(protocol.Source, protocol.SourceOrganizeImports))
+	assert.True(t, codeActionKindContains(protocol.SourceFixAll, protocol.SourceFixAll))
+	assert.False(t, codeActionKindContains(protocol.SourceOrganizeImports, protocol.Source))
+	assert.False(t, codeActionKindContains(protocol.Source, "sourceX"))
+}
+
+func TestServerCodeActionKinds(t *testing.T) {
+	assert.Nil(t, serverCodeActionKinds(protocol.ServerCapabilities{}))
+	assert.Nil(t, serverCodeActionKinds(protocol.ServerCapabilities{CodeActionProvider: true}))
+	assert.Equal(t, []protocol.CodeActionKind{}, serverCodeActionKinds(protocol.ServerCapabilities{CodeActionProvider: false}))
+	assert.Equal(t,
+		[]protocol.CodeActionKind{protocol.QuickFix, protocol.SourceOrganizeImports},
+		serverCodeActionKinds(protocol.ServerCapabilities{CodeActionProvider: map[string]any{
+			"codeActionKinds": []any{"quickfix", "source.organizeImports"},
+		}}),
+	)
+}
@@ -70,6 +70,11 @@ func FindReferences(ctx context.Context, client *lsp.Client, symbolName string)
 			return "", fmt.Errorf("failed to get references: %v", err)
 		}
 
+		// Identify the symbol across projects when the server supports it
+		if monikers := monikerSummary(ctx, client, loc); monikers != "" && len(refs) > 0 {
+			allReferences = append(allReferences, fmt.Sprintf("---\n\nSymbol: %s\n%s", symbol.GetName(), monikers))
+		}
+
 		// Group references by file
 		refsByFile := make(map[protocol.DocumentUri][]protocol.Location)
 		for _, ref := range refs {
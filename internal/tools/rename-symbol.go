@@ -3,8 +3,12 @@ package tools
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"unicode/utf16"
 
 	"github.com/isaacphi/mcp-language-server/internal/lsp"
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
@@ -12,8 +16,9 @@ import (
 )
 
 // RenameSymbol renames a symbol (variable, function, class, etc.) at the specified position
-// It uses the LSP rename functionality to handle all references across files
-func RenameSymbol(ctx context.Context, client *lsp.Client, filePath string, line, column int, newName string) (string, error) {
+// It uses the LSP rename functionality to handle all references across files. With
+// dryRun set it returns a unified diff of the changes instead of applying them.
+func RenameSymbol(ctx context.Context, client *lsp.Client, filePath string, line, column int, newName string, dryRun bool) (string, error) {
 	// Open the file if not already open
 	err := client.OpenFile(ctx, filePath)
 	if err != nil {
@@ -27,6 +32,16 @@ func RenameSymbol(ctx context.Context, client *lsp.Client, filePath string, line
 		Character: uint32(column - 1),
 	}
 
+	// Check that there is something to rename at the position before renaming,
+	// so that e.g. keywords and symbols from dependencies are refused
+	var symbolInfo string
+	if supportsPrepareRename(client.ServerCapabilities()) {
+		symbolInfo, err = prepareRename(ctx, client, filePath, position)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	// Create the rename parameters
 	params := protocol.RenameParams{
 		TextDocument: protocol.TextDocumentIdentifier{
@@ -36,9 +51,6 @@ func RenameSymbol(ctx context.Context, client *lsp.Client, filePath string, line
 		NewName:  newName,
 	}
 
-	// Skip the PrepareRename check as it might not be supported by all language servers
-	// Execute the rename directly
-
 	// Execute the rename operation
 	workspaceEdit, err := client.Rename(ctx, params)
 	if err != nil {
@@ -112,16 +124,245 @@ func RenameSymbol(ctx context.Context, client *lsp.Client, filePath string, line
 		locationsBuilder.WriteString(fmt.Sprintf("%s: %s\n", change.URI, change.Locations))
 	}
 
+	if dryRun {
+		if fileCount == 0 || changeCount == 0 {
+			return symbolInfo + "Dry run: renaming the symbol would change nothing. 0 occurrences found.", nil
+		}
+		diff, err := utilities.WorkspaceEditDiff(workspaceEdit)
+		if err != nil {
+			return "", fmt.Errorf("failed to preview changes: %v", err)
+		}
+		return fmt.Sprintf("%sDry run: renaming symbol to '%s' would update %d occurrences across %d files. No files were changed.\n\n%s",
+			symbolInfo, newName, changeCount, fileCount, diff), nil
+	}
+
 	// Apply the workspace edit to files:workspaceEdit
 	if err := utilities.ApplyWorkspaceEdit(workspaceEdit); err != nil {
 		return "", fmt.Errorf("failed to apply changes: %v", err)
 	}
 
 	if fileCount == 0 || changeCount == 0 {
-		return "Failed to rename symbol. 0 occurrences found.", nil
+		return symbolInfo + "Failed to rename symbol. 0 occurrences found.", nil
 	}
 
 	// Generate a summary of changes made
-	return fmt.Sprintf("Successfully renamed symbol to '%s'.\nUpdated %d occurrences across %d files:\n%s",
-		newName, changeCount, fileCount, locationsBuilder.String()), nil
+	return fmt.Sprintf("%sSuccessfully renamed symbol to '%s'.\nUpdated %d occurrences across %d files:\n%s",
+		symbolInfo, newName, changeCount, fileCount, locationsBuilder.String()), nil
+}
+
+// RenameSymbolByName renames the symbol declared with the given name, resolved
+// with the same matching rules as ReadDefinition. Kind, container and filePath
+// narrow down the candidates, and the rename is refused if more than one
+// declaration matches.
+func RenameSymbolByName(ctx context.Context, client *lsp.Client, symbolName, kind, container, filePath, newName string, dryRun bool) (string, error) {
+	var kinds []protocol.SymbolKind
+	if kind != "" {
+		var err error
+		kinds, err = parseSymbolKinds([]string{kind})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if filePath != "" {
+		var err error
+		filePath, err = filepath.Abs(filePath)
+		if err != nil {
+			return "", fmt.Errorf("invalid path: %v", err)
+		}
+	}
+
+	symbolName, results, err := QuerySymbol(ctx, client, symbolName)
+	if err != nil {
+		return "", err
+	}
+
+	candidates := filterRenameCandidates(results, symbolName, kinds, container, filePath)
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("failed to rename symbol: %s not found", symbolName)
+	}
+	if len(candidates) > 1 {
+		var list strings.Builder
+		for i, symbol := range candidates {
+			list.WriteString(fmt.Sprintf("\n  %d. %s", i+1, formatSymbolCandidate(symbol)))
+		}
+		return "", fmt.Errorf("failed to rename symbol: %s is ambiguous, %d symbols match. Narrow it down with kind, container or filePath, or rename by position:%s",
+			symbolName, len(candidates), list.String())
+	}
+
+	symbol := candidates[0]
+	loc := symbol.GetLocation()
+	if err := client.OpenFile(ctx, loc.URI.Path()); err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	position := declarationNamePosition(ctx, client, symbol)
+	result, err := RenameSymbol(ctx, client, loc.URI.Path(), int(position.Line)+1, int(position.Character)+1, newName, dryRun)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Resolved %s\n%s", formatSymbolCandidate(symbol), result), nil
+}
+
+// filterRenameCandidates keeps the workspace symbols named symbolName that
+// match the filters, dropping duplicates reported at the same location
+func filterRenameCandidates(results []protocol.WorkspaceSymbolResult, symbolName string, kinds []protocol.SymbolKind, container, filePath string) []protocol.WorkspaceSymbolResult {
+	seen := make(map[string]bool)
+	var candidates []protocol.WorkspaceSymbolResult
+	for _, symbol := range results {
+		if !matchesSymbolName(symbol, symbolName) {
+			continue
+		}
+		if len(kinds) > 0 && !slices.Contains(kinds, symbol.GetKind()) {
+			continue
+		}
+		if container != "" && !matchesSymbolContainer(symbol, container) {
+			continue
+		}
+		loc := symbol.GetLocation()
+		if filePath != "" && loc.URI.Path() != filePath {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%d:%d", loc.URI, loc.Range.Start.Line, loc.Range.Start.Character)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		candidates = append(candidates, symbol)
+	}
+	return candidates
+}
+
+// matchesSymbolContainer reports whether a symbol's container contains the
+// given text. Some servers, e.g. gopls, qualify method names with their type
+// instead of setting the container, so the qualifier is checked too.
+func matchesSymbolContainer(symbol protocol.WorkspaceSymbolResult, container string) bool {
+	container = strings.ToLower(container)
+	if strings.Contains(strings.ToLower(symbol.GetContainerName()), container) {
+		return true
+	}
+
+	name := symbol.GetName()
+	if index := strings.LastIndexAny(name, ".:"); index > 0 {
+		return strings.Contains(strings.ToLower(name[:index]), container)
+	}
+	return false
+}
+
+// declarationNamePosition returns the position of the name in a symbol's
+// declaration. Workspace symbols may point at the start of the whole
+// declaration, e.g. a "func" keyword, which can't be renamed, so the selection
+// range of the matching document symbol is used when there is one.
+func declarationNamePosition(ctx context.Context, client *lsp.Client, symbol protocol.WorkspaceSymbolResult) protocol.Position {
+	loc := symbol.GetLocation()
+	name := symbol.GetName()
+
+	matches, err := identifyOverlappingSymbols(ctx, client, loc)
+	if err != nil {
+		toolsLogger.Debug("Failed to get document symbols: %v", err)
+	}
+
+	// The smallest document symbol with the same name is the declaration
+	var best *protocol.DocumentSymbol
+	for _, m := range matches {
+		ds, ok := m.Symbol.(*protocol.DocumentSymbol)
+		if !ok || (ds.Name != name && !strings.HasSuffix(ds.Name, "."+name) && !strings.HasSuffix(ds.Name, "::"+name)) {
+			continue
+		}
+		if best == nil || ds.Range.End.Line-ds.Range.Start.Line < best.Range.End.Line-best.Range.Start.Line {
+			best = ds
+		}
+	}
+	if best != nil {
+		return best.SelectionRange.Start
+	}
+
+	// Otherwise look for the name on the line the symbol starts at
+	content, err := os.ReadFile(loc.URI.Path())
+	if err != nil {
+		return loc.Range.Start
+	}
+	lines := strings.Split(string(content), "\n")
+	if int(loc.Range.Start.Line) >= len(lines) {
+		return loc.Range.Start
+	}
+	line := lines[loc.Range.Start.Line]
+	start := utf16OffsetToByte(line, loc.Range.Start.Character)
+	if index := strings.Index(line[start:], name); index >= 0 {
+		return protocol.Position{
+			Line:      loc.Range.Start.Line,
+			Character: loc.Range.Start.Character + uint32(len(utf16.Encode([]rune(line[start:start+index])))),
+		}
+	}
+	return loc.Range.Start
+}
+
+// formatSymbolCandidate describes a workspace symbol on one line
+func formatSymbolCandidate(symbol protocol.WorkspaceSymbolResult) string {
+	loc := symbol.GetLocation()
+	description := fmt.Sprintf("%s [%s]", symbol.GetName(), protocol.TableKindMap[symbol.GetKind()])
+	if container := symbol.GetContainerName(); container != "" {
+		description += " in " + container
+	}
+	return fmt.Sprintf("%s at %s L%d:C%d", description, loc.URI.Path(), loc.Range.Start.Line+1, loc.Range.Start.Character+1)
+}
+
+// prepareRename asks the server whether the symbol at a position can be renamed
+// and describes its exact range and current name
+func prepareRename(ctx context.Context, client *lsp.Client, filePath string, position protocol.Position) (string, error) {
+	result, err := client.PrepareRename(ctx, protocol.PrepareRenameParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: protocol.DocumentUri("file://" + filePath),
+			},
+			Position: position,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to rename symbol: %v", err)
+	}
+
+	var rng protocol.Range
+	var placeholder string
+	switch v := result.Value.(type) {
+	case nil:
+		return "", fmt.Errorf("failed to rename symbol: no symbol that can be renamed at L%d:C%d", position.Line+1, position.Character+1)
+	case protocol.PrepareRenameDefaultBehavior:
+		// The server leaves it to the client to find the word at the position
+		return "", nil
+	case protocol.PrepareRenamePlaceholder:
+		rng = v.Range
+		placeholder = v.Placeholder
+	case protocol.Range:
+		rng = v
+	}
+
+	if placeholder == "" {
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			lines := strings.Split(string(content), "\n")
+			if int(rng.Start.Line) < len(lines) && rng.Start.Line == rng.End.Line {
+				text := lines[rng.Start.Line]
+				placeholder = text[utf16OffsetToByte(text, rng.Start.Character):utf16OffsetToByte(text, rng.End.Character)]
+			}
+		}
+	}
+
+	location := fmt.Sprintf("%s L%d:C%d - L%d:C%d", filePath,
+		rng.Start.Line+1, rng.Start.Character+1, rng.End.Line+1, rng.End.Character+1)
+	if placeholder == "" {
+		return fmt.Sprintf("Symbol at %s\n", location), nil
+	}
+	return fmt.Sprintf("Symbol: '%s' at %s\n", placeholder, location), nil
+}
+
+func supportsPrepareRename(capabilities protocol.ServerCapabilities) bool {
+	options, ok := capabilities.RenameProvider.(map[string]any)
+	if !ok {
+		return false
+	}
+	prepare, _ := options["prepareProvider"].(bool)
+	return prepare
 }
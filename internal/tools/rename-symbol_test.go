@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterRenameCandidates(t *testing.T) {
+	symbol := func(name string, kind protocol.SymbolKind, container, path string, line uint32) protocol.WorkspaceSymbolResult {
+		return &protocol.SymbolInformation{
+			Name:          name,
+			Kind:          kind,
+			ContainerName: container,
+			Location: protocol.Location{
+				URI:   protocol.DocumentUri("file://" + path),
+				Range: protocol.Range{Start: protocol.Position{Line: line}},
+			},
+		}
+	}
+
+	results := []protocol.WorkspaceSymbolResult{
+		symbol("Start", protocol.Method, "Server", "/project/server.go", 10),
+		symbol("Start", protocol.Method, "Client", "/project/client.go", 20),
+		symbol("Start", protocol.Function, "main", "/project/main.go", 5),
+		// Reported twice by some servers
+		symbol("Start", protocol.Function, "main", "/project/main.go", 5),
+		// Fuzzy match from the server
+		symbol("StartServer", protocol.Function, "main", "/project/main.go", 8),
+		// Qualified by its type rather than its container, like gopls does
+		symbol("Worker.Start", protocol.Method, "example.com/project", "/project/worker.go", 3),
+	}
+
+	names := func(candidates []protocol.WorkspaceSymbolResult) []string {
+		var result []string
+		for _, c := range candidates {
+			result = append(result, c.GetContainerName()+"."+c.GetName())
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Server.Start", "Client.Start", "main.Start", "example.com/project.Worker.Start"},
+		names(filterRenameCandidates(results, "Start", nil, "", "")))
+	assert.Equal(t, []string{"Server.Start", "Client.Start", "example.com/project.Worker.Start"},
+		names(filterRenameCandidates(results, "Start", []protocol.SymbolKind{protocol.Method}, "", "")))
+	assert.Equal(t, []string{"example.com/project.Worker.Start"},
+		names(filterRenameCandidates(results, "Start", nil, "worker", "")))
+	assert.Equal(t, []string{"Server.Start"},
+		names(filterRenameCandidates(results, "Start", nil, "server", "")))
+	assert.Equal(t, []string{"Client.Start"},
+		names(filterRenameCandidates(results, "Start", nil, "", "/project/client.go")))
+	assert.Empty(t, filterRenameCandidates(results, "Stop", nil, "", ""))
+}
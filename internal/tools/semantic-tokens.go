@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// nonIdentifierTokenTypes are token types that don't name anything, so they
+// are left out of the semantic_tokens output
+var nonIdentifierTokenTypes = map[string]bool{
+	string(protocol.KeywordType):  true,
+	string(protocol.ModifierType): true,
+	string(protocol.CommentType):  true,
+	string(protocol.StringType):   true,
+	string(protocol.NumberType):   true,
+	string(protocol.RegexpType):   true,
+	string(protocol.OperatorType): true,
+}
+
+// semanticToken is a decoded semantic token with an absolute, 0-indexed position
+type semanticToken struct {
+	Line      uint32
+	Character uint32 // UTF-16 offset in the line
+	Length    uint32 // Length in UTF-16 code units
+	Type      string
+	Modifiers []string
+}
+
+// GetSemanticTokens lists the identifiers in a range of lines of a file with the
+// token type and modifiers the language server classifies them with, e.g. that a
+// variable is a parameter, is readonly or refers to a deprecated function
+func GetSemanticTokens(ctx context.Context, client *lsp.Client, filePath string, startLine, endLine int) (string, error) {
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	options, err := semanticTokensOptions(client.ServerCapabilities())
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+
+	if startLine < 1 {
+		startLine = 1
+	}
+	if endLine < 1 || endLine > len(lines) {
+		endLine = len(lines)
+	}
+	if startLine > endLine {
+		return "", fmt.Errorf("invalid line range: L%d-L%d", startLine, endLine)
+	}
+
+	docIdentifier := protocol.TextDocumentIdentifier{
+		URI: protocol.DocumentUri("file://" + filePath),
+	}
+
+	// Prefer a range request, since the full result for a large file is big.
+	// Tokens outside the range are filtered out below either way.
+	var result protocol.SemanticTokens
+	if supportsSemanticTokensRange(options) {
+		result, err = client.SemanticTokensRange(ctx, protocol.SemanticTokensRangeParams{
+			TextDocument: docIdentifier,
+			Range: protocol.Range{
+				Start: protocol.Position{Line: uint32(startLine - 1)},
+				End: protocol.Position{
+					Line:      uint32(endLine - 1),
+					Character: uint32(len(utf16.Encode([]rune(lines[endLine-1])))),
+				},
+			},
+		})
+	} else {
+		result, err = client.SemanticTokensFull(ctx, protocol.SemanticTokensParams{
+			TextDocument: docIdentifier,
+		})
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get semantic tokens: %v", err)
+	}
+
+	tokens, err := decodeSemanticTokens(result.Data, options.Legend)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	count := 0
+	lastLine := -1
+	for _, token := range tokens {
+		line := int(token.Line)
+		if line < startLine-1 || line > endLine-1 || nonIdentifierTokenTypes[token.Type] {
+			continue
+		}
+
+		if line != lastLine {
+			output.WriteString(fmt.Sprintf("L%d: %s\n", line+1, strings.TrimSpace(lines[line])))
+			lastLine = line
+		}
+
+		start := utf16OffsetToByte(lines[line], token.Character)
+		end := utf16OffsetToByte(lines[line], token.Character+token.Length)
+		output.WriteString(fmt.Sprintf("  C%d %s: %s", token.Character+1, lines[line][start:end], token.Type))
+		if len(token.Modifiers) > 0 {
+			output.WriteString(" [" + strings.Join(token.Modifiers, ", ") + "]")
+		}
+		output.WriteString("\n")
+		count++
+	}
+
+	if count == 0 {
+		return fmt.Sprintf("No semantic tokens found in %s L%d-L%d", filePath, startLine, endLine), nil
+	}
+
+	return fmt.Sprintf("Semantic tokens in %s L%d-L%d (%d identifiers):\n\n%s",
+		filePath, startLine, endLine, count, output.String()), nil
+}
+
+// decodeSemanticTokens decodes the relative encoding of semantic tokens. Each
+// token is five integers: the line relative to the previous token, the start
+// character relative to the previous token if it is on the same line, the
+// length, an index into the legend's token types and a bit set of indexes into
+// the legend's token modifiers.
+func decodeSemanticTokens(data []uint32, legend protocol.SemanticTokensLegend) ([]semanticToken, error) {
+	if len(data)%5 != 0 {
+		return nil, fmt.Errorf("invalid semantic tokens: %d integers is not a multiple of 5", len(data))
+	}
+
+	tokens := make([]semanticToken, 0, len(data)/5)
+	var line, character uint32
+	for i := 0; i < len(data); i += 5 {
+		deltaLine, deltaStart, length, tokenType, modifierSet := data[i], data[i+1], data[i+2], data[i+3], data[i+4]
+
+		if deltaLine > 0 {
+			line += deltaLine
+			character = deltaStart
+		} else {
+			character += deltaStart
+		}
+
+		token := semanticToken{
+			Line:      line,
+			Character: character,
+			Length:    length,
+			Type:      fmt.Sprintf("unknown(%d)", tokenType),
+		}
+		if int(tokenType) < len(legend.TokenTypes) {
+			token.Type = legend.TokenTypes[tokenType]
+		}
+		for bit := 0; modifierSet != 0; bit++ {
+			if modifierSet&1 == 1 {
+				if bit < len(legend.TokenModifiers) {
+					token.Modifiers = append(token.Modifiers, legend.TokenModifiers[bit])
+				} else {
+					token.Modifiers = append(token.Modifiers, fmt.Sprintf("unknown(%d)", bit))
+				}
+			}
+			modifierSet >>= 1
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// semanticTokensOptions returns the semantic tokens options, including the
+// legend, that the server reported in its initialize result
+func semanticTokensOptions(capabilities protocol.ServerCapabilities) (protocol.SemanticTokensOptions, error) {
+	var options protocol.SemanticTokensOptions
+	if capabilities.SemanticTokensProvider == nil {
+		return options, fmt.Errorf("the language server does not support semantic tokens")
+	}
+
+	// The capability is untyped, so decode it through JSON
+	data, err := json.Marshal(capabilities.SemanticTokensProvider)
+	if err != nil {
+		return options, fmt.Errorf("failed to read semantic tokens legend: %v", err)
+	}
+	if err := json.Unmarshal(data, &options); err != nil {
+		return options, fmt.Errorf("failed to read semantic tokens legend: %v", err)
+	}
+	if len(options.Legend.TokenTypes) == 0 {
+		return options, fmt.Errorf("the language server did not provide a semantic tokens legend")
+	}
+
+	return options, nil
+}
+
+func supportsSemanticTokensRange(options protocol.SemanticTokensOptions) bool {
+	if options.Range == nil {
+		return false
+	}
+	if supported, ok := options.Range.Value.(bool); ok {
+		return supported
+	}
+	return options.Range.Value != nil
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSemanticTokens(t *testing.T) {
+	legend := protocol.SemanticTokensLegend{
+		TokenTypes:     []string{"function", "parameter", "variable"},
+		TokenModifiers: []string{"declaration", "readonly", "deprecated"},
+	}
+	data := []uint32{
+		// func Greet(name string)
+		2, 5, 5, 0, 1,
+		0, 6, 4, 1, 1,
+		// name on the next line, then an unknown type on the same line
+		1, 8, 4, 1, 0,
+		0, 10, 3, 7, 0,
+		// deprecated readonly variable, and a modifier outside the legend
+		3, 1, 1, 2, 0b1110,
+	}
+
+	tokens, err := decodeSemanticTokens(data, legend)
+	require.NoError(t, err)
+
+	assert.Equal(t, []semanticToken{
+		{Line: 2, Character: 5, Length: 5, Type: "function", Modifiers: []string{"declaration"}},
+		{Line: 2, Character: 11, Length: 4, Type: "parameter", Modifiers: []string{"declaration"}},
+		{Line: 3, Character: 8, Length: 4, Type: "parameter"},
+		{Line: 3, Character: 18, Length: 3, Type: "unknown(7)"},
+		{Line: 6, Character: 1, Length: 1, Type: "variable", Modifiers: []string{"readonly", "deprecated", "unknown(3)"}},
+	}, tokens)
+}
+
+func TestDecodeSemanticTokensInvalid(t *testing.T) {
+	_, err := decodeSemanticTokens([]uint32{0, 1, 2}, protocol.SemanticTokensLegend{})
+	assert.Error(t, err)
+}
+
+func TestSemanticTokensOptions(t *testing.T) {
+	_, err := semanticTokensOptions(protocol.ServerCapabilities{})
+	assert.Error(t, err)
+
+	options, err := semanticTokensOptions(protocol.ServerCapabilities{
+		SemanticTokensProvider: map[string]any{
+			"legend": map[string]any{
+				"tokenTypes":     []any{"namespace", "type"},
+				"tokenModifiers": []any{"readonly"},
+			},
+			"range": true,
+			"full":  map[string]any{"delta": true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"namespace", "type"}, options.Legend.TokenTypes)
+	assert.True(t, supportsSemanticTokensRange(options))
+}
@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// GetSignatureHelp returns the signatures of the function being called at a
+// position, with the parameter under the cursor highlighted
+func GetSignatureHelp(ctx context.Context, client *lsp.Client, filePath string, line, column int) (string, error) {
+	// Open the file if not already open
+	err := client.OpenFile(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %v", err)
+	}
+
+	// Convert 1-indexed line/column to 0-indexed for LSP protocol
+	params := protocol.SignatureHelpParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: protocol.DocumentUri("file://" + filePath),
+			},
+			Position: protocol.Position{
+				Line:      uint32(line - 1),
+				Character: uint32(column - 1),
+			},
+		},
+		Context: &protocol.SignatureHelpContext{
+			TriggerKind: protocol.SigInvoked,
+		},
+	}
+
+	help, err := client.SignatureHelp(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to get signature help: %v", err)
+	}
+
+	if len(help.Signatures) == 0 {
+		return fmt.Sprintf("No signature help available at %s L%d:C%d. Make sure the position is inside the parentheses of a call.", filePath, line, column), nil
+	}
+
+	return formatSignatureHelp(help), nil
+}
+
+// formatSignatureHelp lists every signature with its parameters. The active
+// parameter is wrapped in «» in the signature label and marked with > in the
+// parameter list.
+func formatSignatureHelp(help protocol.SignatureHelp) string {
+	var result strings.Builder
+
+	activeSignature := int(help.ActiveSignature)
+	if activeSignature >= len(help.Signatures) {
+		activeSignature = 0
+	}
+
+	for i, sig := range help.Signatures {
+		if i > 0 {
+			result.WriteString("\n")
+		}
+
+		// The signature's own active parameter takes precedence, even when
+		// it is the first one
+		activeParameter := int(help.ActiveParameter)
+		if sig.ActiveParameter != nil {
+			activeParameter = int(*sig.ActiveParameter)
+		}
+
+		header := fmt.Sprintf("Signature %d of %d", i+1, len(help.Signatures))
+		if i == activeSignature {
+			header += " (active)"
+		}
+		result.WriteString(header + ":\n")
+
+		offsets := parameterOffsets(sig)
+
+		label := sig.Label
+		if activeParameter < len(sig.Parameters) && offsets[activeParameter][0] >= 0 {
+			start, end := offsets[activeParameter][0], offsets[activeParameter][1]
+			label = sig.Label[:start] + "«" + sig.Label[start:end] + "»" + sig.Label[end:]
+		}
+		result.WriteString("  " + label + "\n")
+
+		if len(sig.Parameters) > 0 {
+			result.WriteString("  Parameters:\n")
+			for j, param := range sig.Parameters {
+				marker := " "
+				if j == activeParameter {
+					marker = ">"
+				}
+				paramLabel, ok := param.Label.Value.(string)
+				if !ok && offsets[j][0] >= 0 {
+					paramLabel = sig.Label[offsets[j][0]:offsets[j][1]]
+				}
+				result.WriteString(fmt.Sprintf("  %s %s\n", marker, paramLabel))
+
+				if param.Documentation != nil {
+					if doc := strings.TrimSpace(markupText(param.Documentation.Value)); doc != "" {
+						for _, docLine := range strings.Split(doc, "\n") {
+							result.WriteString("      " + docLine + "\n")
+						}
+					}
+				}
+			}
+		}
+
+		if sig.Documentation != nil {
+			if doc := strings.TrimSpace(markupText(sig.Documentation.Value)); doc != "" {
+				result.WriteString("  Documentation:\n")
+				for _, docLine := range strings.Split(doc, "\n") {
+					result.WriteString("    " + docLine + "\n")
+				}
+			}
+		}
+	}
+
+	return result.String()
+}
+
+// parameterOffsets returns the byte offsets of each parameter within the
+// signature label, or -1 offsets if a parameter can't be found. Offsets sent by
+// the server are in UTF-16 code units. String labels are searched for in order,
+// starting inside the parentheses, so that repeated labels like "int" map to
+// the right parameter.
+func parameterOffsets(sig protocol.SignatureInformation) [][2]int {
+	offsets := make([][2]int, len(sig.Parameters))
+	searchFrom := strings.Index(sig.Label, "(") + 1
+
+	for i, param := range sig.Parameters {
+		offsets[i] = [2]int{-1, -1}
+
+		switch v := param.Label.Value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			index := strings.Index(sig.Label[searchFrom:], v)
+			if index < 0 {
+				continue
+			}
+			offsets[i] = [2]int{searchFrom + index, searchFrom + index + len(v)}
+			searchFrom += index + len(v)
+		case protocol.Tuple_ParameterInformation_label_Item1:
+			encoded := utf16.Encode([]rune(sig.Label))
+			if v.Fld0 > v.Fld1 || int(v.Fld1) > len(encoded) {
+				continue
+			}
+			offsets[i] = [2]int{
+				len(string(utf16.Decode(encoded[:v.Fld0]))),
+				len(string(utf16.Decode(encoded[:v.Fld1]))),
+			}
+		}
+	}
+
+	return offsets
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSignatureHelp(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected string
+	}{
+		{
+			name: "Label offsets",
+			response: `{
+				"signatures": [{
+					"label": "func Greet(name string, times int) string",
+					"documentation": {"kind": "markdown", "value": "Greet says hello."},
+					"parameters": [{"label": [11, 22]}, {"label": [24, 33], "documentation": "How often"}]
+				}],
+				"activeSignature": 0,
+				"activeParameter": 1
+			}`,
+			expected: "Signature 1 of 1 (active):\n" +
+				"  func Greet(name string, «times int») string\n" +
+				"  Parameters:\n" +
+				"    name string\n" +
+				"  > times int\n" +
+				"      How often\n" +
+				"  Documentation:\n" +
+				"    Greet says hello.\n",
+		},
+		{
+			name: "Overloads with string labels",
+			response: `{
+				"signatures": [
+					{"label": "max(int a, int b)", "parameters": [{"label": "int a"}, {"label": "int b"}]},
+					{"label": "max(int, int, int)", "parameters": [{"label": "int"}, {"label": "int"}, {"label": "int"}], "activeParameter": 2}
+				],
+				"activeSignature": 1
+			}`,
+			expected: "Signature 1 of 2:\n" +
+				"  max(«int a», int b)\n" +
+				"  Parameters:\n" +
+				"  > int a\n" +
+				"    int b\n" +
+				"\n" +
+				"Signature 2 of 2 (active):\n" +
+				"  max(int, int, «int»)\n" +
+				"  Parameters:\n" +
+				"    int\n" +
+				"    int\n" +
+				"  > int\n",
+		},
+		{
+			name: "Signature active parameter of zero",
+			response: `{
+				"signatures": [{"label": "add(int a, int b)", "parameters": [{"label": "int a"}, {"label": "int b"}], "activeParameter": 0}],
+				"activeSignature": 0,
+				"activeParameter": 1
+			}`,
+			expected: "Signature 1 of 1 (active):\n" +
+				"  add(«int a», int b)\n" +
+				"  Parameters:\n" +
+				"  > int a\n" +
+				"    int b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var help protocol.SignatureHelp
+			require.NoError(t, json.Unmarshal([]byte(tt.response), &help))
+			assert.Equal(t, tt.expected, formatSignatureHelp(help))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// ReadTypeDefinition returns the source of the type of a symbol, e.g. the struct
+// that a variable holds. The symbol is identified either by symbolName or by a
+// 1-indexed position in filePath.
+func ReadTypeDefinition(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) (string, error) {
+	return readNavigationTargets(ctx, client, symbolName, filePath, line, column, "type definitions",
+		func(params protocol.TextDocumentPositionParams) ([]protocol.Location, error) {
+			result, err := client.TypeDefinition(ctx, protocol.TypeDefinitionParams{
+				TextDocumentPositionParams: params,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result.Locations()
+		})
+}
+
+// ReadDeclaration returns the source of the declaration of a symbol. For languages
+// that separate declarations from definitions (e.g. C and C++ headers), this is
+// the declaration rather than the implementation.
+func ReadDeclaration(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int) (string, error) {
+	return readNavigationTargets(ctx, client, symbolName, filePath, line, column, "declarations",
+		func(params protocol.TextDocumentPositionParams) ([]protocol.Location, error) {
+			result, err := client.Declaration(ctx, protocol.DeclarationParams{
+				TextDocumentPositionParams: params,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result.Locations()
+		})
+}
@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// GetTypeHierarchy shows the supertypes and/or subtypes of a type, identified either
+// by symbolName or by a 1-indexed position in filePath. direction is one of
+// "up" (supertypes), "down" (subtypes) or "both".
+func GetTypeHierarchy(ctx context.Context, client *lsp.Client, symbolName, filePath string, line, column int, direction string, maxDepth int) (string, error) {
+	if direction == "" {
+		direction = "both"
+	}
+	if direction != "up" && direction != "down" && direction != "both" {
+		return "", fmt.Errorf("invalid direction %q, must be one of up, down or both", direction)
+	}
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
+
+	queryLocations, err := resolveQueryLocations(ctx, client, symbolName, filePath, line, column)
+	if err != nil {
+		return "", err
+	}
+
+	supertypes := func(item protocol.TypeHierarchyItem) ([]protocol.TypeHierarchyItem, error) {
+		return client.Supertypes(ctx, protocol.TypeHierarchySupertypesParams{Item: item})
+	}
+	subtypes := func(item protocol.TypeHierarchyItem) ([]protocol.TypeHierarchyItem, error) {
+		return client.Subtypes(ctx, protocol.TypeHierarchySubtypesParams{Item: item})
+	}
+
+	// Failures for one match are written to the output, so that the
+	// hierarchies of the other matches are still shown
+	var result strings.Builder
+
+	for _, loc := range queryLocations {
+		items, err := client.PrepareTypeHierarchy(ctx, protocol.TypeHierarchyPrepareParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{
+					URI: loc.URI,
+				},
+				Position: loc.Range.Start,
+			},
+		})
+		if err != nil {
+			fmt.Fprintf(&result, "\n---\n%s: Error: %v\n", formatQueryLocation(loc), err)
+			continue
+		}
+
+		for _, item := range items {
+			result.WriteString("\n---\n")
+			writeTypeHierarchyItem(&result, item, 0, "")
+
+			if direction == "up" || direction == "both" {
+				result.WriteString("Supertypes:\n")
+				visited := map[string]bool{typeHierarchyItemKey(item): true}
+				before := result.Len()
+				recurseTypeHierarchy(&result, item, 1, maxDepth, "Supertype", supertypes, visited)
+				if result.Len() == before {
+					result.WriteString("  None\n")
+				}
+			}
+
+			if direction == "down" || direction == "both" {
+				result.WriteString("Subtypes:\n")
+				visited := map[string]bool{typeHierarchyItemKey(item): true}
+				before := result.Len()
+				recurseTypeHierarchy(&result, item, 1, maxDepth, "Subtype", subtypes, visited)
+				if result.Len() == before {
+					result.WriteString("  None\n")
+				}
+			}
+		}
+	}
+
+	if result.Len() == 0 {
+		return fmt.Sprintf("No type hierarchy found for %s", describeQueryTarget(symbolName, filePath, line, column)), nil
+	}
+
+	return result.String(), nil
+}
+
+// recurseTypeHierarchy writes the items related to item (its supertypes or subtypes,
+// depending on next) as an indented tree. Items that were already written are
+// marked instead of expanded again, which protects against cycles.
+func recurseTypeHierarchy(
+	result *strings.Builder, item protocol.TypeHierarchyItem, depth int, maxDepth int, label string,
+	next func(item protocol.TypeHierarchyItem) ([]protocol.TypeHierarchyItem, error), visited map[string]bool,
+) {
+	if depth > maxDepth {
+		return
+	}
+
+	prefix := strings.Repeat(" ", (depth-1)*2+2)
+
+	related, err := next(item)
+	if err != nil {
+		result.WriteString(prefix)
+		result.WriteString("Error: ")
+		result.WriteString(err.Error())
+		result.WriteRune('\n')
+		return
+	}
+
+	// Servers return related types in no particular order
+	sort.Slice(related, func(i, j int) bool {
+		return related[i].Name < related[j].Name
+	})
+
+	for _, relatedItem := range related {
+		key := typeHierarchyItemKey(relatedItem)
+		if visited[key] {
+			result.WriteString(strings.Repeat(" ", (depth-1)*2))
+			fmt.Fprintf(result, "- %s: %s (already shown)\n", label, relatedItem.Name)
+			continue
+		}
+		visited[key] = true
+
+		writeTypeHierarchyItem(result, relatedItem, depth, label)
+		recurseTypeHierarchy(result, relatedItem, depth+1, maxDepth, label, next, visited)
+	}
+}
+
+func writeTypeHierarchyItem(result *strings.Builder, item protocol.TypeHierarchyItem, depth int, label string) {
+	var prefix string
+	if depth != 0 {
+		prefix = strings.Repeat(" ", (depth-1)*2+2)
+
+		result.WriteString(strings.Repeat(" ", (depth-1)*2))
+		result.WriteRune('-')
+		result.WriteString(" " + label + ": ")
+	} else {
+		result.WriteString("Name: ")
+	}
+
+	result.WriteString(item.Name)
+	result.WriteRune('\n')
+
+	result.WriteString(prefix)
+	result.WriteString("Kind: ")
+	result.WriteString(protocol.TableKindMap[item.Kind])
+	result.WriteRune('\n')
+
+	if item.Detail != "" {
+		result.WriteString(prefix)
+		result.WriteString("Detail: ")
+		result.WriteString(item.Detail)
+		result.WriteRune('\n')
+	}
+
+	result.WriteString(prefix)
+	result.WriteString("File: ")
+	result.WriteString(strings.TrimPrefix(string(item.URI), "file://"))
+	result.WriteRune('\n')
+
+	result.WriteString(prefix)
+	fmt.Fprintf(result, "Range: L%d:C%d - L%d:C%d\n",
+		item.Range.Start.Line+1,
+		item.Range.Start.Character+1,
+		item.Range.End.Line+1,
+		item.Range.End.Character+1)
+}
+
+// typeHierarchyItemKey identifies an item by where it is declared
+func typeHierarchyItemKey(item protocol.TypeHierarchyItem) string {
+	return fmt.Sprintf("%s:%d:%d", item.URI, item.SelectionRange.Start.Line, item.SelectionRange.Start.Character)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf16"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/isaacphi/mcp-language-server/internal/lsp"
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
 )
@@ -199,3 +202,88 @@ func QuerySymbol(ctx context.Context, client *lsp.Client, symbolName string) (st
 
 	return symbolName, results, err
 }
+
+// describeWorkspaceEdit summarizes a workspace edit as one line per changed
+// file, sorted by path, with file creations, renames and deletions included
+func describeWorkspaceEdit(edit protocol.WorkspaceEdit) string {
+	var lines []string
+
+	for uri, edits := range edit.Changes {
+		lines = append(lines, fmt.Sprintf("%s: %d edits", strings.TrimPrefix(string(uri), "file://"), len(edits)))
+	}
+
+	for _, change := range edit.DocumentChanges {
+		switch {
+		case change.TextDocumentEdit != nil:
+			lines = append(lines, fmt.Sprintf("%s: %d edits",
+				strings.TrimPrefix(string(change.TextDocumentEdit.TextDocument.URI), "file://"), len(change.TextDocumentEdit.Edits)))
+		case change.CreateFile != nil:
+			lines = append(lines, fmt.Sprintf("%s: created", strings.TrimPrefix(string(change.CreateFile.URI), "file://")))
+		case change.RenameFile != nil:
+			lines = append(lines, fmt.Sprintf("%s: renamed to %s",
+				strings.TrimPrefix(string(change.RenameFile.OldURI), "file://"),
+				strings.TrimPrefix(string(change.RenameFile.NewURI), "file://")))
+		case change.DeleteFile != nil:
+			lines = append(lines, fmt.Sprintf("%s: deleted", strings.TrimPrefix(string(change.DeleteFile.URI), "file://")))
+		}
+	}
+
+	if len(lines) == 0 {
+		return "No changes\n"
+	}
+
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// markupText returns the text of a documentation value from the protocol,
+// which is either a plain string or MarkupContent
+func markupText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case protocol.MarkupContent:
+		return v.Value
+	}
+	return ""
+}
+
+// utf16OffsetToByte converts a character offset in UTF-16 code units, as used
+// by LSP positions, to a byte offset in line. Offsets past the end of the line
+// are clamped to its length.
+func utf16OffsetToByte(line string, offset uint32) int {
+	units := uint32(0)
+	for i, r := range line {
+		if units >= offset {
+			return i
+		}
+		units += uint32(utf16.RuneLen(r))
+	}
+	return len(line)
+}
+
+// matchPathGlob matches a file path against a glob. Globs without a slash
+// match the file name, so "*_test.go" matches test files in any directory.
+// Other relative globs are matched against the path relative to the workspace
+// (the current working directory), and absolute globs against the full path.
+func matchPathGlob(pattern, path string) (bool, error) {
+	pattern = filepath.ToSlash(pattern)
+	if !doublestar.ValidatePattern(pattern) {
+		return false, fmt.Errorf("invalid path glob %q", pattern)
+	}
+
+	if !strings.Contains(pattern, "/") {
+		return doublestar.Match(pattern, filepath.Base(path))
+	}
+
+	if !filepath.IsAbs(pattern) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				if matched, _ := doublestar.Match(strings.TrimPrefix(pattern, "./"), filepath.ToSlash(rel)); matched {
+					return true, nil
+				}
+			}
+		}
+	}
+	return doublestar.Match(pattern, filepath.ToSlash(path))
+}
@@ -3,11 +3,13 @@ package tools
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/isaacphi/mcp-language-server/internal/protocol"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Save original ReadFile function
@@ -454,3 +456,61 @@ func TestFormatLinesWithRanges(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribeWorkspaceEdit(t *testing.T) {
+	edit := protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentUri][]protocol.TextEdit{
+			"file:///project/b.go": {{NewText: "x"}, {NewText: "y"}},
+		},
+		DocumentChanges: []protocol.DocumentChange{
+			{TextDocumentEdit: &protocol.TextDocumentEdit{
+				TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: "file:///project/a.go"},
+				},
+				Edits: []protocol.Or_TextDocumentEdit_edits_Elem{{Value: protocol.TextEdit{NewText: "z"}}},
+			}},
+			{RenameFile: &protocol.RenameFile{OldURI: "file:///project/c.go", NewURI: "file:///project/d.go"}},
+		},
+	}
+
+	expected := "/project/a.go: 1 edits\n" +
+		"/project/b.go: 2 edits\n" +
+		"/project/c.go: renamed to /project/d.go\n"
+	assert.Equal(t, expected, describeWorkspaceEdit(edit))
+	assert.Equal(t, "No changes\n", describeWorkspaceEdit(protocol.WorkspaceEdit{}))
+}
+
+func TestMatchPathGlob(t *testing.T) {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	path := filepath.Join(cwd, "internal", "tools", "hover_test.go")
+
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*_test.go", true},
+		{"internal/**/*.go", true},
+		{"./internal/tools/*", true},
+		{filepath.ToSlash(cwd) + "/**/hover_test.go", true},
+		{"**/hover_test.go", true},
+		{"cmd/**", false},
+		{"*.ts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			matched, err := matchPathGlob(tt.pattern, path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, matched)
+		})
+	}
+
+	// Paths outside the workspace are only matched by their full path
+	matched, err := matchPathGlob("tools/*.go", filepath.Join(filepath.Dir(cwd), "tools", "main.go"))
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	_, err = matchPathGlob("internal/[", path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// workspaceDiagnosticTimeout bounds the workspace/diagnostic request. Servers
+// may hold it open to stream results, so the push cache is used after this.
+const workspaceDiagnosticTimeout = 10 * time.Second
+
+// WorkspaceDiagnosticsOptions filters and limits the output of GetWorkspaceDiagnostics
+type WorkspaceDiagnosticsOptions struct {
+	MinSeverity string // Lowest severity to include: "error", "warning", "info" or "hint". Defaults to "hint"
+	PathGlob    string // Only include files matching this glob, e.g. "internal/**/*.go"
+	Source      string // Only include diagnostics from this source, e.g. "compiler"
+	Code        string // Only include diagnostics with this code
+	Limit       int    // Maximum number of diagnostics to list, or of files in summary mode. Defaults to 100
+	Summary     bool   // Only list the number of diagnostics per file
+}
+
+// GetWorkspaceDiagnostics lists the diagnostics for every file in the workspace,
+// grouped by file. It combines the diagnostics the server has published with the
+// result of a workspace diagnostic pull when the server supports it.
+func GetWorkspaceDiagnostics(ctx context.Context, client *lsp.Client, opts WorkspaceDiagnosticsOptions) (string, error) {
+	minSeverity, err := parseSeverity(opts.MinSeverity)
+	if err != nil {
+		return "", err
+	}
+	if opts.Limit < 1 {
+		opts.Limit = 100
+	}
+
+	diagnostics, note := collectWorkspaceDiagnostics(ctx, client)
+
+	// Filter and count per file
+	byFile := make(map[string][]protocol.Diagnostic)
+	severityCounts := make(map[protocol.DiagnosticSeverity]int)
+	total := 0
+	for uri, fileDiagnostics := range diagnostics {
+		path := strings.TrimPrefix(string(uri), "file://")
+		if opts.PathGlob != "" {
+			ok, err := matchPathGlob(opts.PathGlob, path)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				continue
+			}
+		}
+		for _, diag := range fileDiagnostics {
+			if !matchesDiagnosticFilters(diag, minSeverity, opts.Source, opts.Code) {
+				continue
+			}
+			byFile[path] = append(byFile[path], diag)
+			severityCounts[diagnosticSeverity(diag)]++
+			total++
+		}
+	}
+
+	var output strings.Builder
+	if note != "" {
+		output.WriteString(note + "\n\n")
+	}
+
+	if total == 0 {
+		output.WriteString("No diagnostics found in the workspace")
+		if opts.MinSeverity != "" || opts.PathGlob != "" || opts.Source != "" || opts.Code != "" {
+			output.WriteString(" matching the filters")
+		}
+		return output.String(), nil
+	}
+
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	output.WriteString(fmt.Sprintf("Workspace diagnostics: %d in %d files (%s)\n\n",
+		total, len(paths), formatSeverityCounts(severityCounts)))
+
+	shown, shownFiles := 0, 0
+	for _, path := range paths {
+		if opts.Summary && shownFiles >= opts.Limit || !opts.Summary && shown >= opts.Limit {
+			break
+		}
+		shownFiles++
+
+		fileDiagnostics := byFile[path]
+		sortDiagnostics(fileDiagnostics)
+
+		fileCounts := make(map[protocol.DiagnosticSeverity]int)
+		for _, diag := range fileDiagnostics {
+			fileCounts[diagnosticSeverity(diag)]++
+		}
+		output.WriteString(fmt.Sprintf("%s: %d (%s)\n", path, len(fileDiagnostics), formatSeverityCounts(fileCounts)))
+
+		if opts.Summary {
+			continue
+		}
+		for _, diag := range fileDiagnostics {
+			if shown >= opts.Limit {
+				break
+			}
+			output.WriteString("  " + formatDiagnostic(diag) + "\n")
+			shown++
+		}
+	}
+
+	if hiddenFiles := len(paths) - shownFiles; hiddenFiles > 0 || shown < total && !opts.Summary {
+		output.WriteString("\n")
+		if !opts.Summary {
+			output.WriteString(fmt.Sprintf("Showing %d of %d diagnostics. ", shown, total))
+		}
+		if hiddenFiles > 0 {
+			output.WriteString(fmt.Sprintf("%d more files not shown. ", hiddenFiles))
+		}
+		output.WriteString("Narrow them down with filters")
+		if !opts.Summary {
+			output.WriteString(", or use summary mode to see counts per file")
+		}
+		output.WriteString(".\n")
+	}
+
+	return output.String(), nil
+}
+
+// collectWorkspaceDiagnostics merges the diagnostics pushed by the server with
+// those pulled with a workspace/diagnostic request. The note explains why the
+// pull result is missing, if it is.
+func collectWorkspaceDiagnostics(ctx context.Context, client *lsp.Client) (map[protocol.DocumentUri][]protocol.Diagnostic, string) {
+	diagnostics := client.GetAllDiagnostics()
+
+	options, ok := workspaceDiagnosticOptions(client.ServerCapabilities())
+	if !ok {
+		return diagnostics, ""
+	}
+
+	pullCtx, cancel := context.WithTimeout(ctx, workspaceDiagnosticTimeout)
+	defer cancel()
+
+	report, err := client.DiagnosticWorkspace(pullCtx, protocol.WorkspaceDiagnosticParams{
+		Identifier:        options.Identifier,
+		PreviousResultIds: []protocol.PreviousResultId{},
+	})
+	if err != nil {
+		toolsLogger.Error("Failed to pull workspace diagnostics: %v", err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return diagnostics, fmt.Sprintf("Note: the server did not finish computing workspace diagnostics within %s, showing the diagnostics it has published so far", workspaceDiagnosticTimeout)
+		}
+		return diagnostics, "Note: failed to pull workspace diagnostics, showing the diagnostics the server has published so far"
+	}
+
+	for _, item := range report.Items {
+		full, ok := item.Value.(protocol.WorkspaceFullDocumentDiagnosticReport)
+		if !ok {
+			continue
+		}
+		diagnostics[full.URI] = mergeDiagnostics(diagnostics[full.URI], full.Items)
+	}
+
+	return diagnostics, ""
+}
+
+// workspaceDiagnosticOptions returns the server's pull diagnostic options if
+// it supports workspace diagnostics
+func workspaceDiagnosticOptions(capabilities protocol.ServerCapabilities) (protocol.DiagnosticOptions, bool) {
+	if capabilities.DiagnosticProvider == nil {
+		return protocol.DiagnosticOptions{}, false
+	}
+	switch v := capabilities.DiagnosticProvider.Value.(type) {
+	case protocol.DiagnosticOptions:
+		return v, v.WorkspaceDiagnostics
+	case protocol.DiagnosticRegistrationOptions:
+		return v.DiagnosticOptions, v.WorkspaceDiagnostics
+	}
+	return protocol.DiagnosticOptions{}, false
+}
+
+// mergeDiagnostics appends the diagnostics in b that aren't already in a
+func mergeDiagnostics(a, b []protocol.Diagnostic) []protocol.Diagnostic {
+	key := func(diag protocol.Diagnostic) string {
+		return fmt.Sprintf("%v|%d|%s|%v|%s", diag.Range, diag.Severity, diag.Source, diag.Code, diag.Message)
+	}
+
+	seen := make(map[string]bool, len(a))
+	merged := make([]protocol.Diagnostic, 0, len(a)+len(b))
+	for _, diag := range a {
+		seen[key(diag)] = true
+		merged = append(merged, diag)
+	}
+	for _, diag := range b {
+		if !seen[key(diag)] {
+			seen[key(diag)] = true
+			merged = append(merged, diag)
+		}
+	}
+	return merged
+}
+
+// parseSeverity parses a severity name. An empty name means all severities.
+func parseSeverity(name string) (protocol.DiagnosticSeverity, error) {
+	switch strings.ToLower(name) {
+	case "error":
+		return protocol.SeverityError, nil
+	case "warning":
+		return protocol.SeverityWarning, nil
+	case "info", "information":
+		return protocol.SeverityInformation, nil
+	case "hint", "":
+		return protocol.SeverityHint, nil
+	}
+	return 0, fmt.Errorf("invalid severity %q, expected error, warning, info or hint", name)
+}
+
+// matchesDiagnosticFilters reports whether a diagnostic is at least as severe
+// as minSeverity and has the given source and code, if set
+func matchesDiagnosticFilters(diag protocol.Diagnostic, minSeverity protocol.DiagnosticSeverity, source, code string) bool {
+	// Lower values are more severe
+	if diagnosticSeverity(diag) > minSeverity {
+		return false
+	}
+	if source != "" && !strings.EqualFold(diag.Source, source) {
+		return false
+	}
+	if code != "" && (diag.Code == nil || diagnosticCode(diag.Code) != code) {
+		return false
+	}
+	return true
+}
+
+func sortDiagnostics(diagnostics []protocol.Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Range.Start.Line != diagnostics[j].Range.Start.Line {
+			return diagnostics[i].Range.Start.Line < diagnostics[j].Range.Start.Line
+		}
+		return diagnostics[i].Range.Start.Character < diagnostics[j].Range.Start.Character
+	})
+}
+
+// formatSeverityCounts formats counts like "2 errors, 1 warning", most severe first
+func formatSeverityCounts(counts map[protocol.DiagnosticSeverity]int) string {
+	names := []struct {
+		severity protocol.DiagnosticSeverity
+		name     string
+	}{
+		{protocol.SeverityError, "error"},
+		{protocol.SeverityWarning, "warning"},
+		{protocol.SeverityInformation, "info"},
+		{protocol.SeverityHint, "hint"},
+	}
+
+	var parts []string
+	for _, n := range names {
+		count := counts[n.severity]
+		if count == 0 {
+			continue
+		}
+		name := n.name
+		if count > 1 && name != "info" {
+			name += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", count, name))
+	}
+	return strings.Join(parts, ", ")
+}
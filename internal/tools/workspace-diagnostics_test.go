@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesDiagnosticFilters(t *testing.T) {
+	warning := protocol.Diagnostic{Severity: protocol.SeverityWarning, Source: "staticcheck", Code: "SA4006"}
+	numericCode := protocol.Diagnostic{Severity: protocol.SeverityError, Source: "ts", Code: float64(2304)}
+	largeCode := protocol.Diagnostic{Severity: protocol.SeverityError, Code: float64(1000000)}
+	noSeverity := protocol.Diagnostic{Message: "treated as an error"}
+
+	tests := []struct {
+		name        string
+		diag        protocol.Diagnostic
+		minSeverity protocol.DiagnosticSeverity
+		source      string
+		code        string
+		want        bool
+	}{
+		{"all severities", warning, protocol.SeverityHint, "", "", true},
+		{"below min severity", warning, protocol.SeverityError, "", "", false},
+		{"missing severity is an error", noSeverity, protocol.SeverityError, "", "", true},
+		{"source ignores case", warning, protocol.SeverityHint, "StaticCheck", "", true},
+		{"other source", warning, protocol.SeverityHint, "compiler", "", false},
+		{"string code", warning, protocol.SeverityHint, "", "SA4006", true},
+		{"numeric code", numericCode, protocol.SeverityHint, "", "2304", true},
+		{"large numeric code", largeCode, protocol.SeverityHint, "", "1000000", true},
+		{"no code", noSeverity, protocol.SeverityHint, "", "2304", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesDiagnosticFilters(tt.diag, tt.minSeverity, tt.source, tt.code))
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := parseSeverity("Warning")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.SeverityWarning, severity)
+
+	severity, err = parseSeverity("")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.SeverityHint, severity)
+
+	_, err = parseSeverity("fatal")
+	assert.Error(t, err)
+}
+
+func TestMergeDiagnostics(t *testing.T) {
+	pushed := []protocol.Diagnostic{{Message: "unused variable", Severity: protocol.SeverityWarning}}
+	pulled := []protocol.Diagnostic{
+		{Message: "unused variable", Severity: protocol.SeverityWarning},
+		{Message: "undefined: foo", Severity: protocol.SeverityError},
+	}
+
+	merged := mergeDiagnostics(pushed, pulled)
+	require.Len(t, merged, 2)
+	assert.Equal(t, "undefined: foo", merged[1].Message)
+}
+
+func TestFormatSeverityCounts(t *testing.T) {
+	counts := map[protocol.DiagnosticSeverity]int{
+		protocol.SeverityHint:        1,
+		protocol.SeverityError:       2,
+		protocol.SeverityInformation: 3,
+	}
+	assert.Equal(t, "2 errors, 3 info, 1 hint", formatSeverityCounts(counts))
+}
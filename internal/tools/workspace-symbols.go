@@ -0,0 +1,193 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/lsp"
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+)
+
+// WorkspaceSymbolsOptions controls filtering and pagination for SearchWorkspaceSymbols
+type WorkspaceSymbolsOptions struct {
+	Kinds     []string // Symbol kinds to include, e.g. "Function", "Struct". Empty means all kinds
+	Container string   // Only include symbols whose container name contains this string
+	PathGlob  string   // Only include symbols in files matching this glob
+	Limit     int      // Maximum number of results to return
+	Offset    int      // Number of ranked results to skip
+}
+
+// SearchWorkspaceSymbols searches the workspace for symbols matching a query.
+// Unlike ReadDefinition it keeps partial matches, ranking them by how closely
+// they match the query.
+func SearchWorkspaceSymbols(ctx context.Context, client *lsp.Client, query string, opts WorkspaceSymbolsOptions) (string, error) {
+	kinds, err := parseSymbolKinds(opts.Kinds)
+	if err != nil {
+		return "", err
+	}
+
+	results, err := doQuerySymbol(ctx, client, query)
+	if err != nil {
+		return "", err
+	}
+
+	type rankedSymbol struct {
+		symbol protocol.WorkspaceSymbolResult
+		rank   int
+		score  float64
+		path   string
+	}
+
+	var matches []rankedSymbol
+	for _, symbol := range results {
+		if len(kinds) > 0 && !slices.Contains(kinds, symbol.GetKind()) {
+			continue
+		}
+
+		if opts.Container != "" && !strings.Contains(strings.ToLower(symbol.GetContainerName()), strings.ToLower(opts.Container)) {
+			continue
+		}
+
+		path := strings.TrimPrefix(string(symbol.GetLocation().URI), "file://")
+		if opts.PathGlob != "" {
+			ok, err := matchPathGlob(opts.PathGlob, path)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		var score float64
+		switch v := symbol.(type) {
+		case *protocol.WorkspaceSymbol:
+			score = v.Score
+		}
+
+		matches = append(matches, rankedSymbol{
+			symbol: symbol,
+			rank:   symbolMatchRank(symbol, query),
+			score:  score,
+			path:   path,
+		})
+	}
+
+	if len(matches) == 0 {
+		return fmt.Sprintf("No symbols found matching: %s", query), nil
+	}
+
+	// Better matches first, then fall back to the server's score and a stable
+	// name and path order so that output is deterministic
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].symbol.GetName() != matches[j].symbol.GetName() {
+			return matches[i].symbol.GetName() < matches[j].symbol.GetName()
+		}
+		return matches[i].path < matches[j].path
+	})
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := max(opts.Offset, 0)
+	if offset >= len(matches) {
+		return fmt.Sprintf("Found %d symbols matching %s, offset %d is past the end of the results", len(matches), query, offset), nil
+	}
+	end := min(offset+limit, len(matches))
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "Found %d symbols matching %s (showing %d-%d)\n", len(matches), query, offset+1, end)
+
+	for i, m := range matches[offset:end] {
+		symbol := m.symbol
+		loc := symbol.GetLocation()
+
+		// Some servers only return a URI and expect the client to resolve
+		// the range lazily through workspaceSymbol/resolve
+		if ws, ok := symbol.(*protocol.WorkspaceSymbol); ok {
+			if _, uriOnly := ws.Location.Value.(protocol.LocationUriOnly); uriOnly {
+				resolved, err := client.ResolveWorkspaceSymbol(ctx, *ws)
+				if err != nil {
+					toolsLogger.Warn("Failed to resolve workspace symbol %s: %v", ws.Name, err)
+				} else {
+					loc = resolved.GetLocation()
+				}
+			}
+		}
+
+		fmt.Fprintf(&result, "\n%d. %s [%s]", offset+i+1, symbol.GetName(), protocol.TableKindMap[symbol.GetKind()])
+		if container := symbol.GetContainerName(); container != "" {
+			fmt.Fprintf(&result, " in %s", container)
+		}
+		if slices.Contains(symbol.GetTags(), protocol.DeprecatedSymbol) {
+			result.WriteString(" (deprecated)")
+		}
+		result.WriteRune('\n')
+
+		fmt.Fprintf(&result, "   %s L%d:C%d\n",
+			strings.TrimPrefix(string(loc.URI), "file://"),
+			loc.Range.Start.Line+1,
+			loc.Range.Start.Character+1)
+	}
+
+	if end < len(matches) {
+		fmt.Fprintf(&result, "\n%d more results. Use offset %d to see the next page.\n", len(matches)-end, end)
+	}
+
+	return result.String(), nil
+}
+
+// symbolMatchRank orders symbols by how well they match the query. Lower is better.
+func symbolMatchRank(symbol protocol.WorkspaceSymbolResult, query string) int {
+	name := symbol.GetName()
+	qualified := name
+	if container := symbol.GetContainerName(); container != "" {
+		qualified = container + "." + name
+	}
+
+	lowerQuery := strings.ToLower(query)
+	switch {
+	case name == query || qualified == query:
+		return 0
+	case strings.EqualFold(name, query) || strings.EqualFold(qualified, query):
+		return 1
+	case strings.HasPrefix(name, query):
+		return 2
+	case strings.HasPrefix(strings.ToLower(name), lowerQuery):
+		return 3
+	case strings.Contains(strings.ToLower(name), lowerQuery) || strings.Contains(strings.ToLower(qualified), lowerQuery):
+		return 4
+	default:
+		// Fuzzy matches from the server
+		return 5
+	}
+}
+
+// parseSymbolKinds converts kind names like "function" or "Struct" to SymbolKinds
+func parseSymbolKinds(names []string) ([]protocol.SymbolKind, error) {
+	var kinds []protocol.SymbolKind
+	for _, name := range names {
+		found := false
+		for kind, kindName := range protocol.TableKindMap {
+			if strings.EqualFold(kindName, name) {
+				kinds = append(kinds, kind)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown symbol kind: %s", name)
+		}
+	}
+	return kinds, nil
+}
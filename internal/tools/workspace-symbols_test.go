@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbolMatchRank(t *testing.T) {
+	symbol := func(name, container string) protocol.WorkspaceSymbolResult {
+		return &protocol.SymbolInformation{Name: name, ContainerName: container, Kind: protocol.Function}
+	}
+
+	tests := []struct {
+		name     string
+		symbol   protocol.WorkspaceSymbolResult
+		query    string
+		expected int
+	}{
+		{"Exact match", symbol("FooBar", ""), "FooBar", 0},
+		{"Qualified match", symbol("Method", "TestStruct"), "TestStruct.Method", 0},
+		{"Case insensitive match", symbol("FooBar", ""), "foobar", 1},
+		{"Prefix", symbol("FooBar", ""), "Foo", 2},
+		{"Case insensitive prefix", symbol("FooBar", ""), "foo", 3},
+		{"Substring", symbol("FooBar", ""), "bar", 4},
+		{"Fuzzy", symbol("FooBar", ""), "fbr", 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, symbolMatchRank(tc.symbol, tc.query))
+		})
+	}
+}
+
+func TestParseSymbolKinds(t *testing.T) {
+	kinds, err := parseSymbolKinds([]string{"function", "Struct"})
+	assert.NoError(t, err)
+	assert.Equal(t, []protocol.SymbolKind{protocol.Function, protocol.Struct}, kinds)
+
+	_, err = parseSymbolKinds([]string{"NotAKind"})
+	assert.Error(t, err)
+}
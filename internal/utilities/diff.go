@@ -0,0 +1,191 @@
+package utilities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiff returns a unified diff with three lines of context between two
+// versions of a file. It returns an empty string if the contents are equal.
+func UnifiedDiff(path string, before, after []byte) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: "a" + path,
+		ToFile:   "b" + path,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate diff: %w", err)
+	}
+	return diff, nil
+}
+
+// WorkspaceEditDiff previews a workspace edit without writing anything. It
+// returns a unified diff for every file with text edits, preceded by a line for
+// each file that would be created, renamed or deleted.
+func WorkspaceEditDiff(edit protocol.WorkspaceEdit) (string, error) {
+	original := make(map[string][]byte)
+	current := make(map[string][]byte)
+	// Text edits for a renamed file refer to its new path, which doesn't exist yet
+	renamedFrom := make(map[string]string)
+	var paths []string
+	var operations []string
+
+	load := func(path string) ([]byte, error) {
+		if content, ok := current[path]; ok {
+			return content, nil
+		}
+		readPath := path
+		if oldPath, ok := renamedFrom[path]; ok {
+			readPath = oldPath
+		}
+		content, err := osReadFile(readPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		original[path] = content
+		current[path] = content
+		paths = append(paths, path)
+		return content, nil
+	}
+
+	applyEdits := func(uri protocol.DocumentUri, edits []protocol.TextEdit) error {
+		path := strings.TrimPrefix(string(uri), "file://")
+		content, err := load(path)
+		if err != nil {
+			return err
+		}
+		newContent, err := ApplyTextEditsToContent(content, edits)
+		if err != nil {
+			return fmt.Errorf("failed to apply edits to %s: %w", path, err)
+		}
+		current[path] = newContent
+		return nil
+	}
+
+	// Sort changes by path for consistent output
+	uris := make([]string, 0, len(edit.Changes))
+	for uri := range edit.Changes {
+		uris = append(uris, string(uri))
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		if err := applyEdits(protocol.DocumentUri(uri), edit.Changes[protocol.DocumentUri(uri)]); err != nil {
+			return "", err
+		}
+	}
+
+	for _, change := range edit.DocumentChanges {
+		switch {
+		case change.TextDocumentEdit != nil:
+			textEdits := make([]protocol.TextEdit, len(change.TextDocumentEdit.Edits))
+			for i, e := range change.TextDocumentEdit.Edits {
+				var err error
+				textEdits[i], err = e.AsTextEdit()
+				if err != nil {
+					return "", fmt.Errorf("invalid edit type: %w", err)
+				}
+			}
+			if err := applyEdits(change.TextDocumentEdit.TextDocument.URI, textEdits); err != nil {
+				return "", err
+			}
+		case change.CreateFile != nil:
+			path := strings.TrimPrefix(string(change.CreateFile.URI), "file://")
+			operations = append(operations, fmt.Sprintf("Create %s\n", path))
+			if _, ok := current[path]; !ok {
+				original[path] = nil
+				current[path] = []byte{}
+				paths = append(paths, path)
+			}
+		case change.RenameFile != nil:
+			oldPath := strings.TrimPrefix(string(change.RenameFile.OldURI), "file://")
+			newPath := strings.TrimPrefix(string(change.RenameFile.NewURI), "file://")
+			operations = append(operations, fmt.Sprintf("Rename %s to %s\n", oldPath, newPath))
+			renamedFrom[newPath] = oldPath
+		case change.DeleteFile != nil:
+			path := strings.TrimPrefix(string(change.DeleteFile.URI), "file://")
+			operations = append(operations, fmt.Sprintf("Delete %s\n", path))
+		}
+	}
+
+	var result strings.Builder
+	for _, operation := range operations {
+		result.WriteString(operation)
+	}
+	if len(operations) > 0 && len(paths) > 0 {
+		result.WriteString("\n")
+	}
+	// Servers send document changes in no particular order, so sort the
+	// diffs by path. File operations keep their order, which matters.
+	sort.Strings(paths)
+	for _, path := range paths {
+		diff, err := UnifiedDiff(path, original[path], current[path])
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(diff)
+	}
+
+	return result.String(), nil
+}
+
+// ChangedLines returns the number of lines that differ between two versions of
+// a file. A replaced block counts the larger of its old and new line counts.
+func ChangedLines(before, after []byte) int {
+	matcher := difflib.NewMatcher(splitLines(before), splitLines(after))
+
+	changed := 0
+	for _, op := range matcher.GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+		changed += max(op.I2-op.I1, op.J2-op.J1)
+	}
+	return changed
+}
+
+// splitLines splits content into lines that keep their line endings. Unlike
+// difflib.SplitLines it doesn't add an empty line after a trailing newline.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	// Mark a missing final newline the same way git does, which also makes
+	// adding or removing it show up as a change
+	lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	return lines
+}
+
+// LineChanges returns the lines that were removed from and added to a file
+// between two versions, without their line endings
+func LineChanges(before, after []byte) (removed []string, added []string) {
+	a := splitLines(before)
+	b := splitLines(after)
+	matcher := difflib.NewMatcher(a, b)
+
+	for _, op := range matcher.GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+		for _, line := range a[op.I1:op.I2] {
+			removed = append(removed, trimLineEnding(line))
+		}
+		for _, line := range b[op.J1:op.J2] {
+			added = append(added, trimLineEnding(line))
+		}
+	}
+	return removed, added
+}
+
+// trimLineEnding removes the line ending, and the missing newline marker added
+// by splitLines, from a line
+func trimLineEnding(line string) string {
+	line, _, _ = strings.Cut(line, "\n")
+	return strings.TrimSuffix(line, "\r")
+}
@@ -0,0 +1,134 @@
+package utilities
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/isaacphi/mcp-language-server/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	before := []byte("package main\n\nfunc main() {\n\tx:=1\n}\n")
+	after := []byte("package main\n\nfunc main() {\n\tx := 1\n}\n")
+
+	diff, err := UnifiedDiff("/project/main.go", before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, "--- a/project/main.go\n"+
+		"+++ b/project/main.go\n"+
+		"@@ -1,5 +1,5 @@\n"+
+		" package main\n"+
+		" \n"+
+		" func main() {\n"+
+		"-\tx:=1\n"+
+		"+\tx := 1\n"+
+		" }\n", diff)
+
+	diff, err = UnifiedDiff("/project/main.go", []byte("a\nb"), []byte("a\nb\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "--- a/project/main.go\n"+
+		"+++ b/project/main.go\n"+
+		"@@ -1,2 +1,2 @@\n"+
+		" a\n"+
+		"-b\n"+
+		"\\ No newline at end of file\n"+
+		"+b\n", diff)
+
+	diff, err = UnifiedDiff("/project/main.go", before, before)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestChangedLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   int
+	}{
+		{"equal", "a\nb\n", "a\nb\n", 0},
+		{"replace", "a\nb\nc\n", "a\nB\nc\n", 1},
+		{"insert", "a\nc\n", "a\nb\nb\nc\n", 2},
+		{"delete", "a\nb\nc\n", "a\n", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ChangedLines([]byte(tt.before), []byte(tt.after)))
+		})
+	}
+}
+
+func TestLineChanges(t *testing.T) {
+	before := []byte("import (\n\t\"fmt\"\n\t\"strings\"\n)\n")
+	after := []byte("import (\n\t\"fmt\"\n\t\"os\"\n)\n")
+
+	removed, added := LineChanges(before, after)
+	assert.Equal(t, []string{"\t\"strings\""}, removed)
+	assert.Equal(t, []string{"\t\"os\""}, added)
+}
+
+func TestWorkspaceEditDiff(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nvar foo = 1\n"), 0644))
+
+	rng := func(line, start, end uint32) protocol.Range {
+		return protocol.Range{
+			Start: protocol.Position{Line: line, Character: start},
+			End:   protocol.Position{Line: line, Character: end},
+		}
+	}
+
+	diff, err := WorkspaceEditDiff(protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentUri][]protocol.TextEdit{
+			protocol.DocumentUri("file://" + mainPath): {{Range: rng(2, 4, 7), NewText: "bar"}},
+		},
+		DocumentChanges: []protocol.DocumentChange{
+			{DeleteFile: &protocol.DeleteFile{Kind: "delete", URI: protocol.DocumentUri("file://" + dir + "/old.go")}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Delete "+dir+"/old.go\n\n"+
+		"--- a"+mainPath+"\n"+
+		"+++ b"+mainPath+"\n"+
+		"@@ -1,3 +1,3 @@\n"+
+		" package main\n"+
+		" \n"+
+		"-var foo = 1\n"+
+		"+var bar = 1\n", diff)
+
+	// Nothing is written
+	content, err := os.ReadFile(mainPath)
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nvar foo = 1\n", string(content))
+}
+
+func TestWorkspaceEditDiffSortsFiles(t *testing.T) {
+	dir := t.TempDir()
+	aPath := filepath.Join(dir, "a.go")
+	bPath := filepath.Join(dir, "b.go")
+	require.NoError(t, os.WriteFile(aPath, []byte("foo\n"), 0644))
+	require.NoError(t, os.WriteFile(bPath, []byte("foo\n"), 0644))
+
+	edit := func(path string) protocol.DocumentChange {
+		return protocol.DocumentChange{TextDocumentEdit: &protocol.TextDocumentEdit{
+			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: protocol.DocumentUri("file://" + path)},
+			},
+			Edits: []protocol.Or_TextDocumentEdit_edits_Elem{{Value: protocol.TextEdit{
+				Range:   protocol.Range{End: protocol.Position{Character: 3}},
+				NewText: "bar",
+			}}},
+		}}
+	}
+
+	diff, err := WorkspaceEditDiff(protocol.WorkspaceEdit{
+		DocumentChanges: []protocol.DocumentChange{edit(bPath), edit(aPath)},
+	})
+	require.NoError(t, err)
+	assert.Less(t, strings.Index(diff, "--- a"+aPath), strings.Index(diff, "--- a"+bPath))
+}
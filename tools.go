@@ -143,123 +143,704 @@ func (s *mcpServer) registerTools() error {
 		return mcp.NewToolResultText(text), nil
 	})
 
+	implementationTool := mcp.NewTool("implementation",
+		mcp.WithDescription("Find the concrete implementations of an interface, abstract class or method. Returns the complete source of each implementation. Identify the symbol either by name or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of the interface, type or method to find implementations of (e.g. 'Store', 'Store.Get'). Alternatively provide filePath, line and column"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file containing the symbol, when not using symbolName"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number of the symbol (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number of the symbol (1-indexed), when not using symbolName"),
+		),
+	)
+
+	s.mcpServer.AddTool(implementationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
+
+		coreLogger.Debug("Executing implementation for symbol: %s file: %s line: %d column: %d", symbolName, filePath, line, column)
+		text, err := tools.FindImplementations(s.ctx, s.lspClient, symbolName, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to find implementations: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to find implementations: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	typeDefinitionTool := mcp.NewTool("type_definition",
+		mcp.WithDescription("Read the source code of the type of a symbol, for example the struct or class that a variable holds. Identify the symbol either by name or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of the symbol whose type you want (e.g. 'mypackage.MyVariable'). Alternatively provide filePath, line and column"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file containing the symbol, when not using symbolName"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number of the symbol (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number of the symbol (1-indexed), when not using symbolName"),
+		),
+	)
+
+	s.mcpServer.AddTool(typeDefinitionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
+
+		coreLogger.Debug("Executing type_definition for symbol: %s file: %s line: %d column: %d", symbolName, filePath, line, column)
+		text, err := tools.ReadTypeDefinition(s.ctx, s.lspClient, symbolName, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to get type definition: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get type definition: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	declarationTool := mcp.NewTool("declaration",
+		mcp.WithDescription("Read the source code of the declaration of a symbol. In C and C++ this is the declaration in the header rather than the definition. Identify the symbol either by name or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of the symbol whose declaration you want (e.g. 'MyClass::method'). Alternatively provide filePath, line and column"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file containing the symbol, when not using symbolName"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number of the symbol (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number of the symbol (1-indexed), when not using symbolName"),
+		),
+	)
+
+	s.mcpServer.AddTool(declarationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
+
+		coreLogger.Debug("Executing declaration for symbol: %s file: %s line: %d column: %d", symbolName, filePath, line, column)
+		text, err := tools.ReadDeclaration(s.ctx, s.lspClient, symbolName, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to get declaration: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get declaration: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	workspaceSymbolsTool := mcp.NewTool("workspace_symbols",
+		mcp.WithDescription("Search the workspace for symbols whose names match a query, including partial matches. Returns ranked results with their kind, container and location."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The full or partial symbol name to search for (e.g. 'Handler', 'parseConf')"),
+		),
+		mcp.WithArray("kinds",
+			mcp.Description("Only include symbols of these kinds (e.g. 'Function', 'Method', 'Struct', 'Interface', 'Class', 'Variable', 'Constant')"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("container",
+			mcp.Description("Only include symbols whose container name (type, class, package or namespace) contains this text"),
+		),
+		mcp.WithString("pathGlob",
+			mcp.Description("Only include symbols in files matching this glob, relative to the workspace, e.g. 'internal/**/*.go'. A glob without a slash matches file names, e.g. '*_test.go'"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of results to return"),
+			mcp.DefaultNumber(50),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of results to skip, for pagination"),
+			mcp.DefaultNumber(0),
+		),
+	)
+
+	s.mcpServer.AddTool(workspaceSymbolsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		query, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := tools.WorkspaceSymbolsOptions{
+			Kinds:     request.GetStringSlice("kinds", nil),
+			Container: request.GetString("container", ""),
+			PathGlob:  request.GetString("pathGlob", ""),
+			Limit:     request.GetInt("limit", 50),
+			Offset:    request.GetInt("offset", 0),
+		}
+
+		coreLogger.Debug("Executing workspace_symbols for query: %s", query)
+		text, err := tools.SearchWorkspaceSymbols(s.ctx, s.lspClient, query, opts)
+		if err != nil {
+			coreLogger.Error("Failed to search workspace symbols: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to search workspace symbols: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	documentSymbolsTool := mcp.NewTool("document_symbols",
+		mcp.WithDescription("Get an outline of the symbols (types, functions, methods, fields, etc.) defined in a file, with their kinds and line ranges."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file to get the outline for"),
+		),
+		mcp.WithNumber("maxDepth",
+			mcp.Description("Maximum nesting depth to show. 1 shows only top level symbols, 0 shows all levels"),
+			mcp.DefaultNumber(0),
+		),
+		mcp.WithArray("kinds",
+			mcp.Description("Only include symbols of these kinds (e.g. 'Function', 'Method', 'Class'). Parents of matching symbols are still shown"),
+			mcp.WithStringItems(),
+		),
+	)
+
+	s.mcpServer.AddTool(documentSymbolsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		maxDepth := request.GetInt("maxDepth", 0)
+		kinds := request.GetStringSlice("kinds", nil)
+
+		coreLogger.Debug("Executing document_symbols for file: %s", filePath)
+		text, err := tools.GetDocumentSymbols(s.ctx, s.lspClient, filePath, maxDepth, kinds)
+		if err != nil {
+			coreLogger.Error("Failed to get document symbols: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get document symbols: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	locateSymbolTool := mcp.NewTool("locate_symbol",
+		mcp.WithDescription("Find the exact position of a symbol by name, to use with position based tools like hover, rename_symbol or content. Returns every match with its kind, container, 1-indexed line and column of its name and a preview of the line. Names can be qualified, e.g. 'Type.Method', 'mod::fn' or 'Class::method'."),
+		mcp.WithString("symbolName",
+			mcp.Required(),
+			mcp.Description("The name of the symbol to locate, optionally qualified (e.g. 'MyFunction', 'MyType.MyMethod', 'module::function')"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("Only search the symbols defined in this file. This also finds symbols the workspace search doesn't return, like fields and local declarations"),
+		),
+	)
+
+	s.mcpServer.AddTool(locateSymbolTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName, err := request.RequireString("symbolName")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		filePath := request.GetString("filePath", "")
+
+		coreLogger.Debug("Executing locate_symbol for symbol: %s file: %s", symbolName, filePath)
+		text, err := tools.LocateSymbol(s.ctx, s.lspClient, symbolName, filePath)
+		if err != nil {
+			coreLogger.Error("Failed to locate symbol: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to locate symbol: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
 	getDiagnosticsTool := mcp.NewTool("diagnostics",
 		mcp.WithDescription("Get diagnostic information for a specific file from the language server."),
 		mcp.WithString("filePath",
 			mcp.Required(),
-			mcp.Description("The path to the file to get diagnostics for"),
+			mcp.Description("The path to the file to get diagnostics for"),
+		),
+		mcp.WithBoolean("contextLines",
+			mcp.Description("Lines to include around each diagnostic."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("showLineNumbers",
+			mcp.Description("If true, adds line numbers to the output"),
+			mcp.DefaultBool(true),
+		),
+	)
+
+	s.mcpServer.AddTool(getDiagnosticsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		contextLines := request.GetInt("contextLines", 5)
+		showLineNumbers := request.GetBool("showLineNumbers", true)
+
+		coreLogger.Debug("Executing diagnostics for file: %s", filePath)
+		text, err := tools.GetDiagnosticsForFile(s.ctx, s.lspClient, filePath, contextLines, showLineNumbers)
+		if err != nil {
+			coreLogger.Error("Failed to get diagnostics: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get diagnostics: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	workspaceDiagnosticsTool := mcp.NewTool("workspace_diagnostics",
+		mcp.WithDescription("Get diagnostics for every file in the workspace, grouped and counted per file. Use this after a change that may affect other files or packages to find what broke. Combines the diagnostics the server has published with a workspace diagnostic pull when the server supports it."),
+		mcp.WithString("minSeverity",
+			mcp.Description("Only include diagnostics at least this severe"),
+			mcp.Enum("error", "warning", "info", "hint"),
+			mcp.DefaultString("hint"),
+		),
+		mcp.WithString("pathGlob",
+			mcp.Description("Only include files matching this glob, relative to the workspace, e.g. 'internal/**/*.go'. A glob without a slash matches file names, e.g. '*_test.go'"),
+		),
+		mcp.WithString("source",
+			mcp.Description("Only include diagnostics from this source, e.g. 'compiler' or 'eslint'"),
+		),
+		mcp.WithString("code",
+			mcp.Description("Only include diagnostics with this code"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of diagnostics to list, or of files in summary mode. Files past the limit are left out"),
+			mcp.DefaultNumber(100),
+		),
+		mcp.WithBoolean("summary",
+			mcp.Description("If true, only show the number of diagnostics per file. Useful when there are many"),
+			mcp.DefaultBool(false),
+		),
+	)
+
+	s.mcpServer.AddTool(workspaceDiagnosticsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		opts := tools.WorkspaceDiagnosticsOptions{
+			MinSeverity: request.GetString("minSeverity", ""),
+			PathGlob:    request.GetString("pathGlob", ""),
+			Source:      request.GetString("source", ""),
+			Code:        request.GetString("code", ""),
+			Limit:       request.GetInt("limit", 100),
+			Summary:     request.GetBool("summary", false),
+		}
+
+		coreLogger.Debug("Executing workspace_diagnostics with options: %+v", opts)
+		text, err := tools.GetWorkspaceDiagnostics(s.ctx, s.lspClient, opts)
+		if err != nil {
+			coreLogger.Error("Failed to get workspace diagnostics: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get workspace diagnostics: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	codeActionsTool := mcp.NewTool("code_actions",
+		mcp.WithDescription("List the code actions (quick fixes, refactorings and source actions) the language server offers for a range or for a diagnostic in a file. Apply one with apply_code_action."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file to get code actions for"),
+		),
+		mcp.WithString("diagnostic",
+			mcp.Description("The location of a diagnostic as reported by the diagnostics tool (e.g. 'L12:C5'). Alternatively provide startLine"),
+		),
+		mcp.WithNumber("startLine",
+			mcp.Description("The start line of the range (1-indexed), when not using diagnostic"),
+		),
+		mcp.WithNumber("startColumn",
+			mcp.Description("The start column of the range (1-indexed). Defaults to the start of the line"),
+		),
+		mcp.WithNumber("endLine",
+			mcp.Description("The end line of the range (1-indexed). Defaults to startLine"),
+		),
+		mcp.WithNumber("endColumn",
+			mcp.Description("The end column of the range (1-indexed). Defaults to the end of the line"),
+		),
+		mcp.WithArray("kinds",
+			mcp.Description("Only include code actions of these kinds, e.g. 'quickfix', 'refactor.extract', 'source.organizeImports'"),
+			mcp.WithStringItems(),
+		),
+	)
+
+	s.mcpServer.AddTool(codeActionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := tools.CodeActionOptions{
+			StartLine:   request.GetInt("startLine", 0),
+			StartColumn: request.GetInt("startColumn", 0),
+			EndLine:     request.GetInt("endLine", 0),
+			EndColumn:   request.GetInt("endColumn", 0),
+			Diagnostic:  request.GetString("diagnostic", ""),
+			Kinds:       request.GetStringSlice("kinds", nil),
+		}
+
+		coreLogger.Debug("Executing code_actions for file: %s", filePath)
+		text, err := tools.GetCodeActions(s.ctx, s.lspClient, filePath, opts)
+		if err != nil {
+			coreLogger.Error("Failed to get code actions: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get code actions: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	applyCodeActionTool := mcp.NewTool("apply_code_action",
+		mcp.WithDescription("Apply a code action listed by code_actions. Pass the same file and range or diagnostic along with the index and title of the action."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file containing the code action"),
+		),
+		mcp.WithString("diagnostic",
+			mcp.Description("The location of a diagnostic as reported by the diagnostics tool (e.g. 'L12:C5'). Alternatively provide startLine"),
+		),
+		mcp.WithNumber("startLine",
+			mcp.Description("The start line of the range (1-indexed), when not using diagnostic"),
+		),
+		mcp.WithNumber("startColumn",
+			mcp.Description("The start column of the range (1-indexed). Defaults to the start of the line"),
+		),
+		mcp.WithNumber("endLine",
+			mcp.Description("The end line of the range (1-indexed). Defaults to startLine"),
+		),
+		mcp.WithNumber("endColumn",
+			mcp.Description("The end column of the range (1-indexed). Defaults to the end of the line"),
+		),
+		mcp.WithArray("kinds",
+			mcp.Description("Only include code actions of these kinds, e.g. 'quickfix', 'refactor.extract', 'source.organizeImports'"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithNumber("index",
+			mcp.Required(),
+			mcp.Description("The index of the code action to apply (from code_actions output), 1 indexed"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("The title of the code action to apply, as listed by code_actions. The action is not applied if the title at index differs"),
+		),
+	)
+
+	s.mcpServer.AddTool(applyCodeActionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := tools.CodeActionOptions{
+			StartLine:   request.GetInt("startLine", 0),
+			StartColumn: request.GetInt("startColumn", 0),
+			EndLine:     request.GetInt("endLine", 0),
+			EndColumn:   request.GetInt("endColumn", 0),
+			Diagnostic:  request.GetString("diagnostic", ""),
+			Kinds:       request.GetStringSlice("kinds", nil),
+		}
+
+		index, err := request.RequireInt("index")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		title, err := request.RequireString("title")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		coreLogger.Debug("Executing apply_code_action for file: %s index: %d title: %s", filePath, index, title)
+		text, err := tools.ApplyCodeAction(s.ctx, s.lspClient, filePath, opts, index, title)
+		if err != nil {
+			coreLogger.Error("Failed to apply code action: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to apply code action: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	formatFileTool := mcp.NewTool("format_file",
+		mcp.WithDescription("Format a file, or ranges of lines in it, using the language server's formatter. Use dryRun to preview the changes as a unified diff."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file to format"),
+		),
+		mcp.WithArray("ranges",
+			mcp.Description("Only format these lines, written as 'startLine-endLine' or 'line' (1-indexed, inclusive). Formats the whole file if empty"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithNumber("tabSize",
+			mcp.Description("Size of a tab in spaces"),
+			mcp.DefaultNumber(4),
+		),
+		mcp.WithBoolean("insertSpaces",
+			mcp.Description("Prefer spaces over tabs"),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithBoolean("trimTrailingWhitespace",
+			mcp.Description("Trim trailing whitespace on each line"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("dryRun",
+			mcp.Description("If true, return a unified diff of the changes without writing the file"),
+			mcp.DefaultBool(false),
+		),
+	)
+
+	s.mcpServer.AddTool(formatFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		ranges, err := tools.ParseFormatRanges(request.GetStringSlice("ranges", nil))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		opts := tools.FormatOptions{
+			TabSize:                request.GetInt("tabSize", 4),
+			InsertSpaces:           request.GetBool("insertSpaces", true),
+			TrimTrailingWhitespace: request.GetBool("trimTrailingWhitespace", false),
+			Ranges:                 ranges,
+			DryRun:                 request.GetBool("dryRun", false),
+		}
+
+		coreLogger.Debug("Executing format_file for file: %s", filePath)
+		text, err := tools.FormatFile(s.ctx, s.lspClient, filePath, opts)
+		if err != nil {
+			coreLogger.Error("Failed to format file: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to format file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	organizeImportsTool := mcp.NewTool("organize_imports",
+		mcp.WithDescription("Organize the imports of a file using the language server: add missing imports, remove unused ones and sort them. Reports which imports were added or removed."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file to organize imports in"),
+		),
+	)
+
+	s.mcpServer.AddTool(organizeImportsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		coreLogger.Debug("Executing organize_imports for file: %s", filePath)
+		text, err := tools.OrganizeImports(s.ctx, s.lspClient, filePath)
+		if err != nil {
+			coreLogger.Error("Failed to organize imports: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to organize imports: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	getCodeLensTool := mcp.NewTool("get_codelens",
+		mcp.WithDescription("Get code lens hints for a given file from the language server, such as commands to run a test or tidy a module. Use execute_codelens to run one."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file to get code lens information for"),
+		),
+	)
+
+	s.mcpServer.AddTool(getCodeLensTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		coreLogger.Debug("Executing get_codelens for file: %s", filePath)
+		text, err := tools.GetCodeLens(s.ctx, s.lspClient, filePath)
+		if err != nil {
+			coreLogger.Error("Failed to get code lens: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get code lens: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	executeCodeLensTool := mcp.NewTool("execute_codelens",
+		mcp.WithDescription("Execute a code lens command for a given file and lens index. Reports the edits the command applied and the messages the language server sent while it ran."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file containing the code lens to execute"),
+		),
+		mcp.WithNumber("index",
+			mcp.Required(),
+			mcp.Description("The index of the code lens to execute (from get_codelens output), 1 indexed"),
+		),
+	)
+
+	s.mcpServer.AddTool(executeCodeLensTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		index, err := request.RequireInt("index")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		coreLogger.Debug("Executing execute_codelens for file: %s index: %d", filePath, index)
+		text, err := tools.ExecuteCodeLens(s.ctx, s.lspClient, filePath, index)
+		if err != nil {
+			coreLogger.Error("Failed to execute code lens: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to execute code lens: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	listCommandsTool := mcp.NewTool("list_commands",
+		mcp.WithDescription("List the commands the language server advertises, such as gopls.add_import or gopls.tidy. Run them with execute_command."),
+		mcp.WithString("filter",
+			mcp.Description("Only list commands containing this text, case insensitive"),
+		),
+	)
+
+	s.mcpServer.AddTool(listCommandsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filter := request.GetString("filter", "")
+
+		coreLogger.Debug("Executing list_commands with filter: %s", filter)
+		text, err := tools.ListCommands(s.lspClient, filter)
+		if err != nil {
+			coreLogger.Error("Failed to list commands: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list commands: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	executeCommandTool := mcp.NewTool("execute_command",
+		mcp.WithDescription("Execute a command the language server advertises (see list_commands). Reports the command's result, the edits it applied and the messages the language server sent while it ran."),
+		mcp.WithString("command",
+			mcp.Required(),
+			mcp.Description("The command to execute, e.g. gopls.add_import"),
+		),
+		mcp.WithString("arguments",
+			mcp.Description(`The command's arguments as JSON: an array with one element per argument, or a single value if the command takes one argument, e.g. {"ImportPath": "strings", "URI": "file:///path/to/file.go"}`),
+		),
+	)
+
+	s.mcpServer.AddTool(executeCommandTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		command, err := request.RequireString("command")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		arguments := request.GetString("arguments", "")
+
+		coreLogger.Debug("Executing execute_command for command: %s arguments: %s", command, arguments)
+		text, err := tools.ExecuteCommand(s.ctx, s.lspClient, command, arguments)
+		if err != nil {
+			coreLogger.Error("Failed to execute command: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to execute command: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	hoverTool := mcp.NewTool("hover",
+		mcp.WithDescription("Get hover information (type, documentation) for a symbol. Identify the symbol either by name, optionally within a file, or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of a symbol declared in the workspace (e.g. 'mypackage.MyFunction', 'MyType.MyMethod'). Symbols of dependencies and the standard library can't be found by name, use the filePath, line and column of a usage instead"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file to get hover information for. With symbolName, only symbols declared in this file are used"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number where the hover is requested (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number where the hover is requested (1-indexed), when not using symbolName"),
+		),
+	)
+
+	s.mcpServer.AddTool(hoverTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
+
+		coreLogger.Debug("Executing hover for symbol: %s file: %s line: %d column: %d", symbolName, filePath, line, column)
+		text, err := tools.GetHoverInfo(s.ctx, s.lspClient, symbolName, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to get hover information: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get hover information: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	signatureHelpTool := mcp.NewTool("signature_help",
+		mcp.WithDescription("Get the signatures of the function being called at a position inside a call's parentheses, including all overloads, their parameters and documentation. The parameter at the position is highlighted."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file containing the call"),
 		),
-		mcp.WithBoolean("contextLines",
-			mcp.Description("Lines to include around each diagnostic."),
-			mcp.DefaultBool(false),
+		mcp.WithNumber("line",
+			mcp.Required(),
+			mcp.Description("The line number inside the call (1-indexed)"),
 		),
-		mcp.WithBoolean("showLineNumbers",
-			mcp.Description("If true, adds line numbers to the output"),
-			mcp.DefaultBool(true),
+		mcp.WithNumber("column",
+			mcp.Required(),
+			mcp.Description("The column number inside the call's parentheses (1-indexed)"),
 		),
 	)
 
-	s.mcpServer.AddTool(getDiagnosticsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.mcpServer.AddTool(signatureHelpTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract arguments
 		filePath, err := request.RequireString("filePath")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		contextLines := request.GetInt("contextLines", 5)
-		showLineNumbers := request.GetBool("showLineNumbers", true)
+		line, err := request.RequireInt("line")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-		coreLogger.Debug("Executing diagnostics for file: %s", filePath)
-		text, err := tools.GetDiagnosticsForFile(s.ctx, s.lspClient, filePath, contextLines, showLineNumbers)
+		column, err := request.RequireInt("column")
 		if err != nil {
-			coreLogger.Error("Failed to get diagnostics: %v", err)
-			return mcp.NewToolResultError(fmt.Sprintf("failed to get diagnostics: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		coreLogger.Debug("Executing signature_help for file: %s line: %d column: %d", filePath, line, column)
+		text, err := tools.GetSignatureHelp(s.ctx, s.lspClient, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to get signature help: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get signature help: %v", err)), nil
 		}
 		return mcp.NewToolResultText(text), nil
 	})
 
-	// Uncomment to add codelens tools
-	//
-	// getCodeLensTool := mcp.NewTool("get_codelens",
-	// 	mcp.WithDescription("Get code lens hints for a given file from the language server."),
-	// 	mcp.WithString("filePath",
-	// 		mcp.Required(),
-	// 		mcp.Description("The path to the file to get code lens information for"),
-	// 	),
-	// )
-	//
-	// s.mcpServer.AddTool(getCodeLensTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// 	// Extract arguments
-	// 	filePath, ok := request.Params.Arguments["filePath"].(string)
-	// 	if !ok {
-	// 		return mcp.NewToolResultError("filePath must be a string"), nil
-	// 	}
-	//
-	// 	coreLogger.Debug("Executing get_codelens for file: %s", filePath)
-	// 	text, err := tools.GetCodeLens(s.ctx, s.lspClient, filePath)
-	// 	if err != nil {
-	// 		coreLogger.Error("Failed to get code lens: %v", err)
-	// 		return mcp.NewToolResultError(fmt.Sprintf("failed to get code lens: %v", err)), nil
-	// 	}
-	// 	return mcp.NewToolResultText(text), nil
-	// })
-	//
-	// executeCodeLensTool := mcp.NewTool("execute_codelens",
-	// 	mcp.WithDescription("Execute a code lens command for a given file and lens index."),
-	// 	mcp.WithString("filePath",
-	// 		mcp.Required(),
-	// 		mcp.Description("The path to the file containing the code lens to execute"),
-	// 	),
-	// 	mcp.WithNumber("index",
-	// 		mcp.Required(),
-	// 		mcp.Description("The index of the code lens to execute (from get_codelens output), 1 indexed"),
-	// 	),
-	// )
-	//
-	// s.mcpServer.AddTool(executeCodeLensTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// 	// Extract arguments
-	// 	filePath, ok := request.Params.Arguments["filePath"].(string)
-	// 	if !ok {
-	// 		return mcp.NewToolResultError("filePath must be a string"), nil
-	// 	}
-	//
-	// 	// Handle both float64 and int for index due to JSON parsing
-	// 	var index int
-	// 	switch v := request.Params.Arguments["index"].(type) {
-	// 	case float64:
-	// 		index = int(v)
-	// 	case int:
-	// 		index = v
-	// 	default:
-	// 		return mcp.NewToolResultError("index must be a number"), nil
-	// 	}
-	//
-	// 	coreLogger.Debug("Executing execute_codelens for file: %s index: %d", filePath, index)
-	// 	text, err := tools.ExecuteCodeLens(s.ctx, s.lspClient, filePath, index)
-	// 	if err != nil {
-	// 		coreLogger.Error("Failed to execute code lens: %v", err)
-	// 		return mcp.NewToolResultError(fmt.Sprintf("failed to execute code lens: %v", err)), nil
-	// 	}
-	// 	return mcp.NewToolResultText(text), nil
-	// })
-
-	hoverTool := mcp.NewTool("hover",
-		mcp.WithDescription("Get hover information (type, documentation) for a symbol at the specified position."),
+	completionTool := mcp.NewTool("completion",
+		mcp.WithDescription("Get the completion candidates the language server suggests at a position, e.g. after 'object.' to see which methods and fields exist. Returns the top candidates with their kind, detail and short documentation."),
 		mcp.WithString("filePath",
 			mcp.Required(),
-			mcp.Description("The path to the file to get hover information for"),
+			mcp.Description("The path to the file"),
 		),
 		mcp.WithNumber("line",
 			mcp.Required(),
-			mcp.Description("The line number where the hover is requested (1-indexed)"),
+			mcp.Description("The line number to complete at (1-indexed)"),
 		),
 		mcp.WithNumber("column",
 			mcp.Required(),
-			mcp.Description("The column number where the hover is requested (1-indexed)"),
+			mcp.Description("The column number to complete at (1-indexed), usually just after a '.' or a partial name"),
+		),
+		mcp.WithString("prefix",
+			mcp.Description("Only include candidates starting with this prefix (case insensitive)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of candidates to return"),
+			mcp.DefaultNumber(20),
 		),
 	)
 
-	s.mcpServer.AddTool(hoverTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.mcpServer.AddTool(completionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract arguments
 		filePath, err := request.RequireString("filePath")
 		if err != nil {
@@ -276,20 +857,89 @@ func (s *mcpServer) registerTools() error {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		coreLogger.Debug("Executing hover for file: %s line: %d column: %d", filePath, line, column)
-		text, err := tools.GetHoverInfo(s.ctx, s.lspClient, filePath, line, column)
+		prefix := request.GetString("prefix", "")
+		limit := request.GetInt("limit", 20)
+
+		coreLogger.Debug("Executing completion for file: %s line: %d column: %d prefix: %s", filePath, line, column, prefix)
+		text, err := tools.GetCompletions(s.ctx, s.lspClient, filePath, line, column, prefix, limit)
 		if err != nil {
-			coreLogger.Error("Failed to get hover information: %v", err)
-			return mcp.NewToolResultError(fmt.Sprintf("failed to get hover information: %v", err)), nil
+			coreLogger.Error("Failed to get completions: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get completions: %v", err)), nil
 		}
 		return mcp.NewToolResultText(text), nil
 	})
 
-	renameSymbolTool := mcp.NewTool("rename_symbol",
-		mcp.WithDescription("Rename a symbol (variable, function, class, etc.) at the specified position and update all references throughout the codebase."),
+	inlayHintsTool := mcp.NewTool("inlay_hints",
+		mcp.WithDescription("Show lines of a file with the inlay hints an editor would display, such as inferred variable types and parameter names at call sites. Hints are inserted inline between « and » markers and are not part of the source."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file"),
+		),
+		mcp.WithNumber("startLine",
+			mcp.Description("The first line to show (1-indexed). Defaults to the start of the file"),
+		),
+		mcp.WithNumber("endLine",
+			mcp.Description("The last line to show (1-indexed, inclusive). Defaults to the end of the file"),
+		),
+	)
+
+	s.mcpServer.AddTool(inlayHintsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		startLine := request.GetInt("startLine", 1)
+		endLine := request.GetInt("endLine", 0)
+
+		coreLogger.Debug("Executing inlay_hints for file: %s lines: %d-%d", filePath, startLine, endLine)
+		text, err := tools.GetInlayHints(s.ctx, s.lspClient, filePath, startLine, endLine)
+		if err != nil {
+			coreLogger.Error("Failed to get inlay hints: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get inlay hints: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	semanticTokensTool := mcp.NewTool("semantic_tokens",
+		mcp.WithDescription("List the identifiers in a range of lines of a file with the semantic token type and modifiers the language server classifies them with. Use this to tell parameters from local variables, spot readonly variables, calls to deprecated functions or macros, where the syntax alone doesn't say."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path to the file"),
+		),
+		mcp.WithNumber("startLine",
+			mcp.Description("The first line to list identifiers for (1-indexed). Defaults to the start of the file"),
+		),
+		mcp.WithNumber("endLine",
+			mcp.Description("The last line to list identifiers for (1-indexed, inclusive). Defaults to the end of the file"),
+		),
+	)
+
+	s.mcpServer.AddTool(semanticTokensTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		startLine := request.GetInt("startLine", 1)
+		endLine := request.GetInt("endLine", 0)
+
+		coreLogger.Debug("Executing semantic_tokens for file: %s lines: %d-%d", filePath, startLine, endLine)
+		text, err := tools.GetSemanticTokens(s.ctx, s.lspClient, filePath, startLine, endLine)
+		if err != nil {
+			coreLogger.Error("Failed to get semantic tokens: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get semantic tokens: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	highlightsTool := mcp.NewTool("highlights",
+		mcp.WithDescription("Find every occurrence within a file of the symbol at a position, with each one marked as a Read or a Write of the symbol (or Text when the server can't tell), and the surrounding code. Useful for seeing where a variable is assigned versus used before refactoring a function."),
 		mcp.WithString("filePath",
 			mcp.Required(),
-			mcp.Description("The path to the file containing the symbol to rename"),
+			mcp.Description("The path to the file containing the symbol"),
 		),
 		mcp.WithNumber("line",
 			mcp.Required(),
@@ -299,39 +949,201 @@ func (s *mcpServer) registerTools() error {
 			mcp.Required(),
 			mcp.Description("The column number where the symbol is located (1-indexed)"),
 		),
+	)
+
+	s.mcpServer.AddTool(highlightsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		line, err := request.RequireInt("line")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		column, err := request.RequireInt("column")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		coreLogger.Debug("Executing highlights for file: %s line: %d column: %d", filePath, line, column)
+		text, err := tools.GetHighlights(s.ctx, s.lspClient, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to get highlights: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get highlights: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	renameSymbolTool := mcp.NewTool("rename_symbol",
+		mcp.WithDescription("Rename a symbol (variable, function, class, etc.) and update all references throughout the codebase. Identify the symbol either by symbolName or by its position. The server first checks that the symbol can be renamed. Use dryRun to review the changes as a diff before applying them."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of the symbol to rename, e.g. 'ParseConfig' or 'Server.Start'. The rename is refused if the name matches more than one declaration, and the candidates are listed"),
+		),
+		mcp.WithString("kind",
+			mcp.Description("With symbolName, only consider symbols of this kind (e.g. 'Function', 'Method', 'Struct', 'Variable', 'Constant')"),
+		),
+		mcp.WithString("container",
+			mcp.Description("With symbolName, only consider symbols whose container name (type, class, package or namespace) contains this text"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file containing the symbol to rename. Required with line and column, optional with symbolName to only consider declarations in this file"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number where the symbol is located (1-indexed). Required if symbolName is not given"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number where the symbol is located (1-indexed). Required if symbolName is not given"),
+		),
 		mcp.WithString("newName",
 			mcp.Required(),
 			mcp.Description("The new name for the symbol"),
 		),
+		mcp.WithBoolean("dryRun",
+			mcp.Description("If true, return a unified diff of every affected file without changing anything"),
+			mcp.DefaultBool(false),
+		),
 	)
 
 	s.mcpServer.AddTool(renameSymbolTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract arguments
-		filePath, err := request.RequireString("filePath")
+		newName, err := request.RequireString("newName")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		newName, err := request.RequireString("newName")
+		dryRun := request.GetBool("dryRun", false)
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+
+		var text string
+		if symbolName != "" {
+			kind := request.GetString("kind", "")
+			container := request.GetString("container", "")
+
+			coreLogger.Debug("Executing rename_symbol for symbol: %s kind: %s container: %s newName: %s dryRun: %v", symbolName, kind, container, newName, dryRun)
+			text, err = tools.RenameSymbolByName(s.ctx, s.lspClient, symbolName, kind, container, filePath, newName, dryRun)
+		} else {
+			if filePath == "" {
+				return mcp.NewToolResultError("either symbolName or filePath, line and column are required"), nil
+			}
+
+			var line, column int
+			line, err = request.RequireInt("line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			column, err = request.RequireInt("column")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			coreLogger.Debug("Executing rename_symbol for file: %s line: %d column: %d newName: %s dryRun: %v", filePath, line, column, newName, dryRun)
+			text, err = tools.RenameSymbol(s.ctx, s.lspClient, filePath, line, column, newName, dryRun)
+		}
+		if err != nil {
+			coreLogger.Error("Failed to rename symbol: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to rename symbol: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	moveFileTool := mcp.NewTool("move_file",
+		mcp.WithDescription("Move or rename a file or directory. Before moving it, the language server updates the files that refer to it, such as imports, where the server supports it. Use this instead of moving files with shell commands and fixing imports by hand."),
+		mcp.WithString("oldPath",
+			mcp.Required(),
+			mcp.Description("The path to the file or directory to move"),
+		),
+		mcp.WithString("newPath",
+			mcp.Required(),
+			mcp.Description("The new path for the file or directory. Missing parent directories are created"),
+		),
+	)
+
+	s.mcpServer.AddTool(moveFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		oldPath, err := request.RequireString("oldPath")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		line, err := request.RequireInt("line")
+		newPath, err := request.RequireString("newPath")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		column, err := request.RequireInt("column")
+		coreLogger.Debug("Executing move_file from: %s to: %s", oldPath, newPath)
+		text, err := tools.MoveFile(s.ctx, s.lspClient, oldPath, newPath)
+		if err != nil {
+			coreLogger.Error("Failed to move file: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to move file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	createFileTool := mcp.NewTool("create_file",
+		mcp.WithDescription("Create a new file with the given content and open it in the language server, so that diagnostics and other tools work on it right away. Use this instead of creating source files with shell commands."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path of the file to create. Missing parent directories are created"),
+		),
+		mcp.WithString("content",
+			mcp.Description("The content of the new file"),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("If true, replace the file if it already exists"),
+			mcp.DefaultBool(false),
+		),
+	)
+
+	s.mcpServer.AddTool(createFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		coreLogger.Debug("Executing rename_symbol for file: %s line: %d column: %d newName: %s", filePath, line, column, newName)
-		text, err := tools.RenameSymbol(s.ctx, s.lspClient, filePath, line, column, newName)
+		content := request.GetString("content", "")
+		overwrite := request.GetBool("overwrite", false)
+
+		coreLogger.Debug("Executing create_file for file: %s overwrite: %v", filePath, overwrite)
+		text, err := tools.CreateFile(s.ctx, s.lspClient, filePath, content, overwrite)
 		if err != nil {
-			coreLogger.Error("Failed to rename symbol: %v", err)
-			return mcp.NewToolResultError(fmt.Sprintf("failed to rename symbol: %v", err)), nil
+			coreLogger.Error("Failed to create file: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	deleteFileTool := mcp.NewTool("delete_file",
+		mcp.WithDescription("Delete a file or directory and let the language server know, so that it forgets the file and its diagnostics."),
+		mcp.WithString("filePath",
+			mcp.Required(),
+			mcp.Description("The path of the file or directory to delete"),
+		),
+		mcp.WithBoolean("recursive",
+			mcp.Description("Must be true to delete a directory and everything in it"),
+			mcp.DefaultBool(false),
+		),
+	)
+
+	s.mcpServer.AddTool(deleteFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		filePath, err := request.RequireString("filePath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		recursive := request.GetBool("recursive", false)
+
+		coreLogger.Debug("Executing delete_file for file: %s recursive: %v", filePath, recursive)
+		text, err := tools.DeleteFile(s.ctx, s.lspClient, filePath, recursive)
+		if err != nil {
+			coreLogger.Error("Failed to delete file: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to delete file: %v", err)), nil
 		}
 		return mcp.NewToolResultText(text), nil
 	})
@@ -342,6 +1154,15 @@ func (s *mcpServer) registerTools() error {
 			mcp.Required(),
 			mcp.Description("The name of the symbol whose callers you want to find (e.g. 'mypackage.MyFunction', 'MyType.MyMethod')"),
 		),
+		mcp.WithNumber("depth",
+			mcp.Description("How many levels of calls to follow. Each function is expanded once and the graph is limited to 100 functions"),
+			mcp.DefaultNumber(1),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: an indented text tree, nested JSON, a Mermaid flowchart or a Graphviz DOT digraph"),
+			mcp.Enum("text", "json", "mermaid", "dot"),
+			mcp.DefaultString("text"),
+		),
 	)
 	s.mcpServer.AddTool(callersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract arguments
@@ -350,8 +1171,11 @@ func (s *mcpServer) registerTools() error {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		coreLogger.Debug("Executing callers for symbol: %s", symbolName)
-		text, err := tools.GetCallers(s.ctx, s.lspClient, symbolName, 1)
+		depth := request.GetInt("depth", 1)
+		format := request.GetString("format", "text")
+
+		coreLogger.Debug("Executing callers for symbol: %s depth: %d format: %s", symbolName, depth, format)
+		text, err := tools.GetCallers(s.ctx, s.lspClient, symbolName, depth, format)
 		if err != nil {
 			coreLogger.Error("Failed to find callers: %v", err)
 			return mcp.NewToolResultError(fmt.Sprintf("failed to find callers: %v", err)), nil
@@ -365,6 +1189,15 @@ func (s *mcpServer) registerTools() error {
 			mcp.Required(),
 			mcp.Description("The name of the symbol whose callees you want to find (e.g. 'mypackage.MyFunction', 'MyType.MyMethod')"),
 		),
+		mcp.WithNumber("depth",
+			mcp.Description("How many levels of calls to follow. Each function is expanded once and the graph is limited to 100 functions"),
+			mcp.DefaultNumber(1),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: an indented text tree, nested JSON, a Mermaid flowchart or a Graphviz DOT digraph"),
+			mcp.Enum("text", "json", "mermaid", "dot"),
+			mcp.DefaultString("text"),
+		),
 	)
 	s.mcpServer.AddTool(calleesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract arguments
@@ -373,8 +1206,11 @@ func (s *mcpServer) registerTools() error {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		coreLogger.Debug("Executing callees for symbol: %s", symbolName)
-		text, err := tools.GetCallees(s.ctx, s.lspClient, symbolName, 1)
+		depth := request.GetInt("depth", 1)
+		format := request.GetString("format", "text")
+
+		coreLogger.Debug("Executing callees for symbol: %s depth: %d format: %s", symbolName, depth, format)
+		text, err := tools.GetCallees(s.ctx, s.lspClient, symbolName, depth, format)
 		if err != nil {
 			coreLogger.Error("Failed to find callees: %v", err)
 			return mcp.NewToolResultError(fmt.Sprintf("failed to find callees: %v", err)), nil
@@ -382,41 +1218,142 @@ func (s *mcpServer) registerTools() error {
 		return mcp.NewToolResultText(text), nil
 	})
 
-	contentTool := mcp.NewTool("content",
-		mcp.WithDescription("Read the source code definition of a symbol (function, type, constant, etc.) at the specified location."),
-		mcp.WithString("filePath",
+	callPathTool := mcp.NewTool("call_path",
+		mcp.WithDescription("Find the shortest chains of calls from one function to another, e.g. how an HTTP handler ends up calling a database function. Returns each function on the path and the location of every call."),
+		mcp.WithString("source",
 			mcp.Required(),
-			mcp.Description("The path to the file"),
+			mcp.Description("The name of the function the call chain starts from (e.g. 'mypackage.HandleRequest', 'MyType.MyMethod')"),
 		),
-		mcp.WithNumber("line",
+		mcp.WithString("target",
 			mcp.Required(),
-			mcp.Description("The line number where the content is requested (1-indexed)"),
+			mcp.Description("The name of the function the call chain ends at"),
 		),
-		mcp.WithNumber("column",
-			mcp.Required(),
-			mcp.Description("The column number where the content is requested (1-indexed)"),
+		mcp.WithNumber("depth",
+			mcp.Description("The maximum number of calls in a chain"),
+			mcp.DefaultNumber(6),
 		),
 	)
-
-	s.mcpServer.AddTool(contentTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.mcpServer.AddTool(callPathTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Extract arguments
-		filePath, err := request.RequireString("filePath")
+		source, err := request.RequireString("source")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		line, err := request.RequireInt("line")
+		target, err := request.RequireString("target")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		column, err := request.RequireInt("column")
+		depth := request.GetInt("depth", 6)
+
+		coreLogger.Debug("Executing call_path from: %s to: %s depth: %d", source, target, depth)
+		text, err := tools.FindCallPath(s.ctx, s.lspClient, source, target, depth)
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			coreLogger.Error("Failed to find call path: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to find call path: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	typeHierarchyTool := mcp.NewTool("type_hierarchy",
+		mcp.WithDescription("Show the type hierarchy of a class, interface or struct: the types it extends or implements (up) and the types that extend or implement it (down). Identify the type either by name or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of the type (e.g. 'MyClass', 'mypackage.MyInterface'). Alternatively provide filePath, line and column"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file containing the type, when not using symbolName"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number of the type (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number of the type (1-indexed), when not using symbolName"),
+		),
+		mcp.WithString("direction",
+			mcp.Description("Which part of the hierarchy to show: supertypes (up), subtypes (down) or both"),
+			mcp.Enum("up", "down", "both"),
+			mcp.DefaultString("both"),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description("How many levels of the hierarchy to show in each direction"),
+			mcp.DefaultNumber(2),
+		),
+	)
+	s.mcpServer.AddTool(typeHierarchyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
+		direction := request.GetString("direction", "both")
+		depth := request.GetInt("depth", 2)
+
+		coreLogger.Debug("Executing type_hierarchy for symbol: %s file: %s line: %d column: %d direction: %s depth: %d", symbolName, filePath, line, column, direction, depth)
+		text, err := tools.GetTypeHierarchy(s.ctx, s.lspClient, symbolName, filePath, line, column, direction, depth)
+		if err != nil {
+			coreLogger.Error("Failed to get type hierarchy: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get type hierarchy: %v", err)), nil
 		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	monikerTool := mcp.NewTool("moniker",
+		mcp.WithDescription("Get the monikers of a symbol: stable identifiers with a scheme, identifier, uniqueness and kind (import, export or local) that can be used to look the symbol up in other repositories or code indexes. Identify the symbol either by name or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of the symbol (e.g. 'mypackage.MyFunction', 'MyType.MyMethod'). Alternatively provide filePath, line and column"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file containing the symbol, when not using symbolName"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number of the symbol (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number of the symbol (1-indexed), when not using symbolName"),
+		),
+	)
+	s.mcpServer.AddTool(monikerTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
+
+		coreLogger.Debug("Executing moniker for symbol: %s file: %s line: %d column: %d", symbolName, filePath, line, column)
+		text, err := tools.GetMonikers(s.ctx, s.lspClient, symbolName, filePath, line, column)
+		if err != nil {
+			coreLogger.Error("Failed to get monikers: %v", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get monikers: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+
+	contentTool := mcp.NewTool("content",
+		mcp.WithDescription("Read the source code definition of a symbol (function, type, constant, etc.). Identify the symbol either by name, optionally within a file, or by position."),
+		mcp.WithString("symbolName",
+			mcp.Description("The name of a symbol declared in the workspace (e.g. 'mypackage.MyFunction', 'MyType.MyMethod'). Symbols of dependencies and the standard library can't be found by name, use the filePath, line and column of a usage instead"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The path to the file. With symbolName, only symbols declared in this file are used"),
+		),
+		mcp.WithNumber("line",
+			mcp.Description("The line number where the content is requested (1-indexed), when not using symbolName"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("The column number where the content is requested (1-indexed), when not using symbolName"),
+		),
+	)
+
+	s.mcpServer.AddTool(contentTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract arguments
+		symbolName := request.GetString("symbolName", "")
+		filePath := request.GetString("filePath", "")
+		line := request.GetInt("line", 0)
+		column := request.GetInt("column", 0)
 
-		coreLogger.Debug("Executing content for file: %s line: %d column: %d", filePath, line, column)
-		text, err := tools.GetContentInfo(s.ctx, s.lspClient, filePath, line, column)
+		coreLogger.Debug("Executing content for symbol: %s file: %s line: %d column: %d", symbolName, filePath, line, column)
+		text, err := tools.GetContentInfo(s.ctx, s.lspClient, symbolName, filePath, line, column)
 		if err != nil {
 			coreLogger.Error("Failed to get content information: %v", err)
 			return mcp.NewToolResultError(fmt.Sprintf("failed to get content: %v", err)), nil